@@ -50,7 +50,9 @@ func main() {
 	}
 
 	//This function resets the ADC - to ensure its ready to write to the registers
-	adc.Restart(startpin, pwdnpin)
+	if err := adc.Restart(startpin, pwdnpin); err != nil {
+		log.Fatal(err)
+	}
 
 	// Initialize a register using the built in register object. Register addressed
 	// for all registers on the ADS126x are stored in the constants file for easy use
@@ -80,10 +82,15 @@ func main() {
 	registerdata := []byte{Power.Setvalue, Interface.Setvalue, Mode0.Setvalue, Mode1.Setvalue, Mode2.Setvalue, Inpmux.Setvalue}
 
 	//This actually writes the data to the register. The starting register needs to be specified - in this case it is the POWER register
-	piadcs.WriteToConsecutiveRegisters(spi0, Power.Address, registerdata)
+	if err := piadcs.WriteToConsecutiveRegisters(spi0, Power.Address, registerdata); err != nil {
+		log.Fatal(err)
+	}
 
 	//This reads the data from registers so we can check that the ADC is working and that we correctly wrote the data to the registers
-	incomingregdata := piadcs.ReadFromConsecutiveRegisters(spi0, Power.Address, 6)
+	incomingregdata, err := piadcs.ReadFromConsecutiveRegisters(spi0, Power.Address, 6)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	//Since the output of ReadFromConsecutiveRegisters is also a byte slice we can compare it to the slice we sent to make sure that there were no communication errors and the registers were written as intended. The registermatch function is intended for this purpose
 	if piadcs.RegisterMatch(incomingregdata, registerdata) {
@@ -103,28 +110,22 @@ func main() {
 
 	//In this example we want to change only MODE2 and INPMUX registers so that we can check the internal temperature of the ADC chip (see thermocouple measurement example for a real use case of this)
 
-	//It is important to reset the setvalue of the register that you want to change by setting it to zero
-	Mode2.Setvalue = 0
-
-	Mode2.Setregister([]byte{adc.MODE2_GAIN_1, adc.MODE2_DR_20})
+	//Instead of combining constants with Setregister (which requires resetting Setvalue to zero first) we can use the typed register settings. Each setting has its own field so conflicting options can't be combined, and SetConfig replaces the old value completely
+	if err := Mode2.SetConfig(adc.Mode2{Gain: adc.Gain1, Rate: adc.Rate20}); err != nil {
+		log.Fatal(err)
+	}
 
-	Inpmux.Setvalue = 0
 	//This allows us to read the internal temperature sensor (see the datasheet section 9.3.4)
-	Inpmux.Setregister([]byte{adc.INPMUX_muxP_tempSensorP, adc.INPMUX_muxN_tempSensorN})
+	if err := Inpmux.SetConfig(adc.InpMux{Positive: adc.TempSensor, Negative: adc.TempSensor}); err != nil {
+		log.Fatal(err)
+	}
 
 	registerdata2 := []byte{Mode2.Setvalue, Inpmux.Setvalue}
 
-	//Write new values to the registers
-	piadcs.WriteToConsecutiveRegisters(spi0, Mode2.Address, registerdata2)
-
-	incomingregdata2 := piadcs.ReadFromConsecutiveRegisters(spi0, Power.Address, 2)
-
-	if piadcs.RegisterMatch(incomingregdata2, registerdata2) {
-		fmt.Println("registers match")
-	} else {
-		fmt.Println("registers don't match - Perhaps there was an error in the SPI communication or the ADC isn't powered up")
+	//Write new values to the registers. This time the write is verified - the registers are read back and written again (up to 3 times here) if they don't match. If they still don't match the error says which registers differ, which usually means there is an error in the SPI communication or the ADC isn't powered up
+	if err := piadcs.WriteVerifiedToConsecutiveRegisters(spi0, Mode2.Address, registerdata2, 3); err != nil {
+		log.Fatal(err)
 	}
-
-	fmt.Println(incomingregdata2)
+	fmt.Println("registers match")
 
 }
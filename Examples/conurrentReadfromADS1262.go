@@ -69,7 +69,9 @@ func main() {
 	}
 
 	//This function resets the ADC
-	adc.Restart(startpin, pwdnpin)
+	if err := adc.Restart(startpin, pwdnpin); err != nil {
+		log.Fatal(err)
+	}
 
 	//See periph documentation
 	port, err := spireg.Open("")
@@ -102,10 +104,21 @@ func main() {
 	registerdata := []byte{Interface.Setvalue, Mode0.Setvalue, Mode1.Setvalue, Mode2.Setvalue}
 
 	//This actually writes the data to the register
-	piadcs.WriteToConsecutiveRegisters(spi0, Interface.Address, registerdata)
+	if err := piadcs.WriteToConsecutiveRegisters(spi0, Interface.Address, registerdata); err != nil {
+		log.Fatal(err)
+	}
+
+	//The Device keeps its own read buffers, so it is created once and used for every read. Sync reads back the settings just written so it knows the frame layout and the data rate.
+	dev := adc.New(spi0, adc.Pins{Start: startpin, Pwdn: pwdnpin, Drdy: drdypin})
+	if err := dev.Sync(); err != nil {
+		log.Fatal(err)
+	}
 
 	//This reads the data from registers so we can check that the ADC is working and that we correctly wrote the data to the registers
-	incomingregdata := piadcs.ReadFromConsecutiveRegisters(spi0, Interface.Address, 4)
+	incomingregdata, err := piadcs.ReadFromConsecutiveRegisters(spi0, Interface.Address, 4)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	fmt.Println(incomingregdata)
 
@@ -130,7 +143,7 @@ func main() {
 	//This handles reading the data from the adc and passes the results into the data channel.
 	go func() {
 		for {
-			adcdata, err := adc.ContinuousReadCHK(spi0, drdypin)
+			adcdata, err := dev.Read()
 			if err == nil {
 				data <- adcdata
 				data <- int32(time.Since(beginning).Milliseconds())
@@ -147,7 +160,7 @@ func main() {
 		for {
 			incomingvalue, incomingtime := <-data, <-data
 			converteddata := adc.ConvertData(incomingvalue)
-			outputstring := strconv.FormatInt(int64(incomingtime), 10) + "," + strconv.FormatFloat(float64(converteddata), 'f', -1, 64) + "\n"
+			outputstring := strconv.FormatInt(int64(incomingtime), 10) + "," + strconv.FormatFloat(float64(converteddata), 'f', -1, 64) + "," + adc.ClippingOf(incomingvalue).String() + "\n"
 			datafile.WriteString(outputstring)
 		}
 	}()
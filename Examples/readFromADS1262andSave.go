@@ -67,7 +67,9 @@ func main() {
 	}
 
 	//This function resets the ADC
-	adc.Restart(startpin, pwdnpin)
+	if err := adc.Restart(startpin, pwdnpin); err != nil {
+		log.Fatal(err)
+	}
 
 	//See periph documentation
 	port, err := spireg.Open("")
@@ -107,10 +109,15 @@ func main() {
 	registerdata := []byte{Power.Setvalue, Interface.Setvalue, Mode0.Setvalue, Mode1.Setvalue, Mode2.Setvalue, Inpmux.Setvalue}
 
 	//This actually writes the data to the register
-	piadcs.WriteToConsecutiveRegisters(spi0, Power.Address, registerdata)
+	if err := piadcs.WriteToConsecutiveRegisters(spi0, Power.Address, registerdata); err != nil {
+		log.Fatal(err)
+	}
 
 	//This reads the data from registers so we can check that the ADC is working and that we correctly wrote the data to the registers
-	incomingregdata := piadcs.ReadFromConsecutiveRegisters(spi0, Power.Address, 6)
+	incomingregdata, err := piadcs.ReadFromConsecutiveRegisters(spi0, Power.Address, 6)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	fmt.Println(incomingregdata)
 
@@ -138,14 +145,21 @@ func main() {
 		log.Fatal(err)
 	}
 
+	//The Device keeps its own read buffers and knows how the INTERFACE register was set
+	dev := adc.New(spi0, adc.Pins{Start: startpin, Pwdn: pwdnpin, Drdy: drdypin})
+
 	//this for loop will take data continuously, convert it. and write it to the file until ctrl-c is pressed
 	for {
-		adcdata, err := adc.ContinuousReadCHK(spi0, drdypin)
+		sample, err := dev.ReadSample()
 		if err == nil {
-			converteddata := adc.ConvertData(adcdata)
+			//The status byte read with the data tells us if the PGA or the reference was out of range when the sample was taken
+			if sample.Status.Alarm() {
+				fmt.Println("alarm:", sample.Status)
+			}
+			converteddata := adc.ConvertData(sample.Raw)
 			timestamp := time.Since(beginning).Milliseconds()
-			outputstring := strconv.FormatInt(int64(timestamp), 10) + "," + strconv.FormatFloat(float64(converteddata), 'f', -1, 64) + "\n"
-			// this writes the converted data to the file with the format "time, data"
+			outputstring := strconv.FormatInt(int64(timestamp), 10) + "," + strconv.FormatFloat(float64(converteddata), 'f', -1, 64) + "," + sample.Clipping.String() + "\n"
+			// this writes the converted data to the file with the format "time, data, clipping". Clipping is in-range unless the input was beyond full scale or the PGA was overloaded.
 			datafile.WriteString(outputstring)
 			successes = successes + 1
 		} else {
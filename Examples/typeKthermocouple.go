@@ -5,7 +5,6 @@ package main
 import (
 	"fmt"
 	"log"
-	"math"
 	"os"
 	"os/signal"
 	"strconv"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/AnnaKnapp/piadcs"
 	adc "github.com/AnnaKnapp/piadcs/ads126x"
+	"github.com/AnnaKnapp/piadcs/thermocouple"
 
 	"periph.io/x/periph/conn/gpio"
 	"periph.io/x/periph/conn/gpio/gpioreg"
@@ -32,48 +32,6 @@ func closer(exiter chan os.Signal, dataFile *os.File) {
 	os.Exit(0)
 }
 
-//K-type thermocouple conversion polynomial constants
-//constants for converting Temp to Emf
-var c0 float64 = -0.176004136860e-1
-var c1 float64 = 0.389212049750e-1
-var c2 float64 = 0.185587700320e-4
-var c3 float64 = -0.994575928740e-7
-var c4 float64 = 0.318409457190e-9
-var c5 float64 = -0.560728448890e-12
-var c6 float64 = 0.560750590590e-15
-var c7 float64 = -0.320207200030e-18
-var c8 float64 = 0.971511471520e-22
-var c9 float64 = -0.121047212750e-25
-
-var a0 float64 = 0.118597600000
-var a1 float64 = -0.118343200000e-3
-var a2 float64 = 0.126968600000e3
-
-//Based on NIST's data
-func calculateEmfFromTemp(temp float64) float64 {
-	emf := c0 + c1*temp + c2*math.Pow(temp, 2) + c3*math.Pow(temp, 3) + c4*math.Pow(temp, 4) + c5*math.Pow(temp, 5) + c6*math.Pow(temp, 6) + c7*math.Pow(temp, 7) + c8*math.Pow(temp, 8) + c9*math.Pow(temp, 9) + a0*math.Pow(math.E, (a1*math.Pow(temp-a2, 2)))
-	return emf
-}
-
-//K-type thermocouple conversion polynomial constants
-//constants for converting EMF to Temp
-var d0 float64 = 0
-var d1 float64 = 2.5083551e1
-var d2 float64 = 7.860106e-2
-var d3 float64 = -2.503131e-1
-var d4 float64 = 8.315270e-2
-var d5 float64 = -1.228034e-2
-var d6 float64 = 9.804036e-4
-var d7 float64 = -4.413030e-5
-var d8 float64 = 1.057734e-6
-var d9 float64 = -1.052755e-8
-
-//Based on NIST's data
-func calculateTempFromEmf(emf float64) float64 {
-	temp := d0 + d1*emf + d2*math.Pow(emf, 2) + d3*math.Pow(emf, 3) + d4*math.Pow(emf, 4) + d5*math.Pow(emf, 5) + d6*math.Pow(emf, 6) + d7*math.Pow(emf, 7) + d8*math.Pow(emf, 8) + d9*math.Pow(emf, 9)
-	return temp
-}
-
 func main() {
 
 	exiter := make(chan os.Signal) //This is the channel that will receive the exit signal (ctrl-c)
@@ -115,7 +73,9 @@ func main() {
 	}
 
 	//This function resets the ADC
-	adc.Restart(startpin, pwdnpin)
+	if err := adc.Restart(startpin, pwdnpin); err != nil {
+		log.Fatal(err)
+	}
 
 	//See periph documentation
 	port, err := spireg.Open("")
@@ -155,7 +115,21 @@ func main() {
 	registerdata := []byte{Power.Setvalue, Interface.Setvalue, Mode0.Setvalue, Mode1.Setvalue, Mode2.Setvalue, Inpmux.Setvalue}
 
 	//This actually writes the data to the register
-	piadcs.WriteToConsecutiveRegisters(spi0, Power.Address, registerdata)
+	if err := piadcs.WriteToConsecutiveRegisters(spi0, Power.Address, registerdata); err != nil {
+		log.Fatal(err)
+	}
+
+	//The Device keeps its own read buffers, so it is created once and used for every read. Sync reads back the settings just written so it knows the frame layout and the data rate.
+	dev := adc.New(spi0, adc.Pins{Start: startpin, Pwdn: pwdnpin, Drdy: drdypin})
+	if err := dev.Sync(); err != nil {
+		log.Fatal(err)
+	}
+
+	//The thermocouple is on AIN9 and AINCOM, with the PGA gain at 32 and the data rate at 20. Offset takes off the 0.2 mV offset seen on this board, measure it with the inputs shorted on other boards.
+	typeK := thermocouple.Channel{Type: thermocouple.K, Positive: adc.AIN9, Negative: adc.AINCOM, Gain: adc.Gain32, Rate: adc.Rate20, Offset: 0.2}
+
+	//The chip temperature sensor is converted with the datasheet constants. The 0.7 degrees subtracted is done due to self-heating of the ADS1262/3. With a Device, dev.ReadTemperature does the switching, averaging and converting in one call.
+	chipsensor := adc.TemperatureCalibration{Offset: -0.7}
 
 	beginning := time.Now()
 
@@ -173,10 +147,10 @@ func main() {
 		//Take 5 temperature readings
 		for n < 5 {
 			n = n + 1
-			adcdata, err := adc.ContinuousReadCHK(spi0, drdypin)
+			adcdata, err := dev.Read()
 			if err == nil {
 				converteddata := adc.ConvertData(adcdata)
-				temperature := (((converteddata * 1000000) - 122400) / 420) + 25
+				temperature := chipsensor.Celsius(converteddata)
 				temperatures = append(temperatures, temperature)
 				//fmt.Println(temperature)
 			}
@@ -194,8 +168,8 @@ func main() {
 		sum = 0
 		temperatures = []float64{}
 
-		//convert ambient temp to a voltage that we can use for cold junction compensation. The 0.7 degrees subtracted is done due to self-heating of the ADS1262/3
-		ambient := calculateEmfFromTemp(tempaverage - 0.7)
+		//use the chip temperature as the cold junction temperature
+		coldjunction := tempaverage
 
 		//Stop conversions so we can change registers to read from the thermocouple instead of the internal temp sensor
 		if err := startpin.Out(gpio.Low); err != nil {
@@ -203,15 +177,18 @@ func main() {
 		}
 
 		//Set PGA gain to 32 and data rate to 20
-		Mode2.Setvalue = 0
-		Mode2.Setregister([]byte{adc.MODE2_GAIN_32, adc.MODE2_DR_20})
+		if err := Mode2.SetConfig(typeK.ChannelConfig().Mode2); err != nil {
+			log.Fatal(err)
+		}
 
 		//set input pins to AIN9 for positive and AINCOM for negative
 		Inpmux.Setvalue = 0
 		Inpmux.Setregister([]byte{adc.INPMUX_muxP_AIN9, adc.INPMUX_muxN_AINCOM})
 
 		//Write the new values to the ADC registers
-		piadcs.WriteToConsecutiveRegisters(spi0, Mode2.Address, []byte{Mode2.Setvalue, Inpmux.Setvalue})
+		if err := piadcs.WriteToConsecutiveRegisters(spi0, Mode2.Address, []byte{Mode2.Setvalue, Inpmux.Setvalue}); err != nil {
+			log.Fatal(err)
+		}
 
 		//Restart conversions on the ADC
 		if err := startpin.Out(gpio.High); err != nil {
@@ -219,14 +196,18 @@ func main() {
 		}
 
 		//Take thermocouple voltage reading
-		adcdata, err := adc.ContinuousReadCHK(spi0, drdypin)
+		adcdata, err := dev.Read()
 		if err == nil {
-			converteddata := adc.ConvertData(adcdata)
-			tctemp := (calculateTempFromEmf(ambient + ((converteddata / 32) * 1000) - 0.2))
+			//the thermocouple voltage at the inputs, taking the gain of 32 into account
+			converteddata := adc.ADC1Scale(adc.InternalReference, typeK.ChannelConfig().Mode2).Volts(adcdata)
+			tctemp, err := typeK.Celsius(adcdata, coldjunction)
+			if err != nil {
+				fmt.Println(err)
+			}
 			fmt.Println(tctemp)
 			timestamp := time.Since(beginning).Milliseconds()
-			outputstring := strconv.FormatInt(int64(timestamp), 10) + "," + strconv.FormatFloat(float64(converteddata), 'f', -1, 64) + "," + strconv.FormatFloat(float64(tctemp), 'f', -1, 64) + "\n"
-			// this writes the converted data to the file with the format "time, data"
+			outputstring := strconv.FormatInt(int64(timestamp), 10) + "," + strconv.FormatFloat(float64(converteddata), 'f', -1, 64) + "," + strconv.FormatFloat(float64(tctemp), 'f', -1, 64) + "," + adc.ClippingOf(adcdata).String() + "\n"
+			// this writes the converted data to the file with the format "time, data, temperature, clipping"
 			datafile.WriteString(outputstring)
 		} else {
 			//if you are getting a high error rate you can print the error to see whats going wrong. I have found that its impossible to get an error rate of 0 and there will always be some instances of the SPI communication failing. I belevie this is because of the raspberry pi operating system not being real time and the CPU taking a break to go do something else. Errors are not recorded in the datafile.
@@ -247,7 +228,9 @@ func main() {
 		Inpmux.Setregister([]byte{adc.INPMUX_muxP_tempSensorP, adc.INPMUX_muxN_tempSensorN})
 
 		//Write new values to
-		piadcs.WriteToConsecutiveRegisters(spi0, Mode2.Address, []byte{Mode2.Setvalue, Inpmux.Setvalue})
+		if err := piadcs.WriteToConsecutiveRegisters(spi0, Mode2.Address, []byte{Mode2.Setvalue, Inpmux.Setvalue}); err != nil {
+			log.Fatal(err)
+		}
 
 	}
 
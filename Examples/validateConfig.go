@@ -0,0 +1,33 @@
+//A quick example showing how to check a config file against the datasheet limits without an ADC connected. Run it with the path to a JSON config file, for example: go run validateConfig.go config.json
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	adc "github.com/AnnaKnapp/piadcs/ads126x"
+)
+
+func main() {
+
+	if len(os.Args) != 2 {
+		log.Fatal("usage: validateConfig config.json")
+	}
+
+	//Settings left out of the file keep their default values. A file containing {"Mode1": {"Filter": 4}, "Mode2": {"Rate": 9}} for example asks for the FIR filter at 1200 SPS, which is an error.
+	violations, err := adc.ValidateFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, v := range violations {
+		fmt.Println(v)
+	}
+
+	//exit with an error code if the config can't work so this can be used in scripts
+	if len(violations.Errors()) > 0 {
+		os.Exit(1)
+	}
+	fmt.Println("config is valid")
+}
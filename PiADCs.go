@@ -1,7 +1,7 @@
 package piadcs
 
 import (
-	"log"
+	"fmt"
 
 	adc "github.com/AnnaKnapp/piadcs/ads126x"
 
@@ -25,6 +25,29 @@ func (reg *Register) Setregister(settings []byte) {
 	}
 }
 
+//Set replaces the register data byte with the settings given. Unlike Setregister it starts from zero, so old settings are never kept by mistake, and it returns an error if two settings are for the same field (like MODE2_DR_20 and MODE2_DR_400). See ads126x.BuildRegister.
+func (reg *Register) Set(settings []byte) error {
+	value, err := adc.BuildRegister(reg.Address, settings...)
+	if err != nil {
+		return err
+	}
+	reg.Setvalue = value
+	return nil
+}
+
+//SetConfig sets the register data byte from one of the typed register settings in the ads126x package (for example ads126x.Mode2). The setting must be for this register.
+func (reg *Register) SetConfig(config adc.RegisterConfig) error {
+	if config.Address() != reg.Address {
+		return fmt.Errorf("piadcs: setting for register 0x%02X used on register %s (0x%02X)", config.Address(), reg.Name, reg.Address)
+	}
+	value, err := config.Encode()
+	if err != nil {
+		return err
+	}
+	reg.Setvalue = value
+	return nil
+}
+
 //Use this to create a register to then set using the setregister function
 func NewRegister(name string, address byte) Register {
 	return Register{
@@ -35,7 +58,7 @@ func NewRegister(name string, address byte) Register {
 }
 
 //This writes data to consecutive registers. You need to specify the starting register and the byte slice of data to write. It will go down the slice and write one byte to each consecutive register starting from the one specified. Please see the datasheet for more information. The WREG opcode is used here and uses the same programming on multiple different TI ADCs
-func WriteToConsecutiveRegisters(connection spi.Conn, startingreg byte, datatowrite []byte) {
+func WriteToConsecutiveRegisters(connection spi.Conn, startingreg byte, datatowrite []byte) error {
 	towrite := []byte{adc.WREG | startingreg, byte(len(datatowrite) - 1)}
 	// for i := range datatowrite {
 	// towrite = append(towrite, datatowrite[i])
@@ -44,24 +67,34 @@ func WriteToConsecutiveRegisters(connection spi.Conn, startingreg byte, datatowr
 	towrite = append(towrite, datatowrite...)
 	toread := make([]byte, len(towrite))
 	if err := connection.Tx(towrite, toread); err != nil {
-		log.Fatal(err)
+		return &adc.SPIError{Op: "WREG", Err: err}
 	}
+	return nil
 }
 
 //Use this function to check what data is stored at what registers. The starting register and the number of registers to read must be specified.
-func ReadFromConsecutiveRegisters(connection spi.Conn, startingreg byte, numbertoread byte) []byte {
+func ReadFromConsecutiveRegisters(connection spi.Conn, startingreg byte, numbertoread byte) ([]byte, error) {
 	asktoread := []byte{adc.RREG | startingreg, numbertoread - 1}
 	blank1 := make([]byte, 2)
 	registerdata := make([]byte, int(numbertoread))
 	blank2 := make([]byte, int(numbertoread))
 	if err := connection.Tx(asktoread, blank1); err != nil {
-		log.Fatal(err)
+		return nil, &adc.SPIError{Op: "RREG", Err: err}
 	}
 	if err := connection.Tx(blank2, registerdata); err != nil {
-		log.Fatal(err)
+		return nil, &adc.SPIError{Op: "RREG", Err: err}
 	}
-	return registerdata
+	return registerdata, nil
+
+}
 
+//This writes data to consecutive registers like WriteToConsecutiveRegisters, then reads them back and writes again, up to retries times, if they don't match. The read only ID register and the reset flag in the POWER register are left out of the comparison since they don't read back what was written. If the registers still don't match the error lists the ones that differ.
+func WriteVerifiedToConsecutiveRegisters(connection spi.Conn, startingreg byte, datatowrite []byte, retries int) error {
+	dev := adc.New(connection, adc.Pins{})
+	//the other registers aren't known here so the settings can't be validated
+	dev.SetValidation(adc.ValidateOff)
+	dev.SetWriteRetries(retries)
+	return dev.WriteVerified(startingreg, datatowrite)
 }
 
 //checks if the data returned from a register read matches the data that was initially sent - use this to ensure you properly set your registers
@@ -0,0 +1,249 @@
+package ads126x
+
+import (
+	"errors"
+	"time"
+)
+
+//ADC2 is the 24 bit auxiliary ADC of the ADS1263. It has its own input multiplexer, reference, gain and data rate and can run at the same time as ADC1, for example as a slow housekeeping channel. It has no DRDY pin so its data is always read with the RDATA2 command.
+
+//ADC2Rate is the ADC2 data rate
+type ADC2Rate byte
+
+const (
+	ADC2Rate10 ADC2Rate = iota
+	ADC2Rate100
+	ADC2Rate400
+	ADC2Rate800
+)
+
+var adc2RateSPS = [...]float64{10, 100, 400, 800}
+
+//SPS is the nominal data rate in samples per second
+func (r ADC2Rate) SPS() float64 {
+	if int(r) >= len(adc2RateSPS) {
+		return 0
+	}
+	return adc2RateSPS[r]
+}
+
+//ADC2Ref selects the ADC2 reference inputs
+type ADC2Ref byte
+
+const (
+	//internal 2.5 V reference
+	ADC2RefInternal ADC2Ref = iota
+	//external reference on AIN0 (positive) and AIN1 (negative)
+	ADC2RefAIN0AIN1
+	//external reference on AIN2 (positive) and AIN3 (negative)
+	ADC2RefAIN2AIN3
+	//external reference on AIN4 (positive) and AIN5 (negative)
+	ADC2RefAIN4AIN5
+	//internal analog supply (VAVDD and VAVSS)
+	ADC2RefSupply
+)
+
+//ADC2Gain is the ADC2 gain
+type ADC2Gain byte
+
+const (
+	ADC2Gain1 ADC2Gain = iota
+	ADC2Gain2
+	ADC2Gain4
+	ADC2Gain8
+	ADC2Gain16
+	ADC2Gain32
+	ADC2Gain64
+	ADC2Gain128
+)
+
+//Factor is the gain in V/V
+func (g ADC2Gain) Factor() int {
+	return 1 << g
+}
+
+//ADC2Config is the ADC2CFG register
+type ADC2Config struct {
+	Rate ADC2Rate
+	Ref  ADC2Ref
+	Gain ADC2Gain
+}
+
+func (ADC2Config) Address() byte { return ADC2CFG_address }
+
+func (c ADC2Config) Encode() (byte, error) {
+	rate, err := setField("ADC2CFG", "DR2", ADC2CFG_DR2_mask, byte(c.Rate))
+	if err != nil {
+		return 0, err
+	}
+	if c.Ref > ADC2RefSupply {
+		return 0, fieldError("ADC2CFG", "REF2", byte(c.Ref))
+	}
+	ref, _ := setField("ADC2CFG", "REF2", ADC2CFG_REF2_mask, byte(c.Ref))
+	gain, err := setField("ADC2CFG", "GAIN2", ADC2CFG_GAIN2_mask, byte(c.Gain))
+	if err != nil {
+		return 0, err
+	}
+	return rate | ref | gain, nil
+}
+
+func (c *ADC2Config) Decode(value byte) error {
+	ref := ADC2Ref(getField(value, ADC2CFG_REF2_mask))
+	if ref > ADC2RefSupply {
+		return fieldError("ADC2CFG", "REF2", byte(ref))
+	}
+	c.Rate = ADC2Rate(getField(value, ADC2CFG_DR2_mask))
+	c.Ref = ref
+	c.Gain = ADC2Gain(getField(value, ADC2CFG_GAIN2_mask))
+	return nil
+}
+
+//ADC2Mux is the ADC2MUX register which selects the ADC2 inputs
+type ADC2Mux struct {
+	Positive Input
+	Negative Input
+}
+
+func (ADC2Mux) Address() byte { return ADC2MUX_address }
+
+func (c ADC2Mux) Encode() (byte, error) {
+	p, err := setField("ADC2MUX", "muxP2", ADC2MUX_muxP2_mask, byte(c.Positive))
+	if err != nil {
+		return 0, err
+	}
+	n, err := setField("ADC2MUX", "muxN2", ADC2MUX_muxN2_mask, byte(c.Negative))
+	if err != nil {
+		return 0, err
+	}
+	return p | n, nil
+}
+
+func (c *ADC2Mux) Decode(value byte) error {
+	c.Positive = Input(getField(value, ADC2MUX_muxP2_mask))
+	c.Negative = Input(getField(value, ADC2MUX_muxN2_mask))
+	return nil
+}
+
+//StartADC2 starts ADC2 conversions with the START2 opcode
+func (d *Device) StartADC2() error {
+	return d.command(START2)
+}
+
+//StopADC2 stops ADC2 conversions with the STOP2 opcode
+func (d *Device) StopADC2() error {
+	return d.command(STOP2)
+}
+
+//ConfigureADC2 sets the ADC2 gain, data rate, reference and inputs
+func (d *Device) ConfigureADC2(config ADC2Config, mux ADC2Mux) error {
+	cfg, err := config.Encode()
+	if err != nil {
+		return err
+	}
+	m, err := mux.Encode()
+	if err != nil {
+		return err
+	}
+	return d.WriteRegisters(ADC2CFG_address, []byte{cfg, m})
+}
+
+//ReadADC2 reads the latest ADC2 conversion data with the RDATA2 opcode. The frame layout follows the INTERFACE register. The output is the unconverted 24 bit value, sign extended to an int32.
+func (d *Device) ReadADC2() (int32, error) {
+	sample, err := d.ReadADC2Sample()
+	return sample.Raw, err
+}
+
+//ReadADC2Sample is like ReadADC2 but returns the status byte with the conversion data. The ADC2 frame is the optional status byte, three data bytes, a pad byte (00h) and the optional check byte, which covers the pad byte as well.
+func (d *Device) ReadADC2Sample() (Sample, error) {
+	return d.applyClipPolicy(d.readADC2Sample())
+}
+
+//readADC2Sample reads the latest ADC2 conversion data without applying the clip policy
+func (d *Device) readADC2Sample() (Sample, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	layout, err := frameLayoutFor(d.regs[INTERFACE_address])
+	if err != nil {
+		return Sample{}, err
+	}
+	n := layout.length() + 1
+	if err := d.conn.Tx(d.readcommand2[:n], d.adc2bytes[:n]); err != nil {
+		return Sample{}, &SPIError{Op: "RDATA2", Err: err}
+	}
+	return layout.decodeADC2(d.adc2bytes[1:n])
+}
+
+//signExtend24 turns three bytes of 24 bit two's complement data into an int32
+func signExtend24(b2, b1, b0 byte) int32 {
+	return int32(uint32(b2)<<24|uint32(b1)<<16|uint32(b0)<<8) >> 8
+}
+
+//ADC2 calibration takes 16 conversions. This is the extra time allowed on top of that before giving up.
+const adc2CalibrationMargin = 100 * time.Millisecond
+
+//calibrateADC2 sends an ADC2 calibration opcode and waits until ADC2 reports new data, which it does once calibration is done. ADC2 must be running and the status byte must be enabled.
+func (d *Device) calibrateADC2(opcode byte) error {
+	if err := d.needStatus(); err != nil {
+		return err
+	}
+	var cfg ADC2Config
+	if err := d.ReadConfig(&cfg); err != nil {
+		return err
+	}
+	period := time.Duration(float64(time.Second) / cfg.Rate.SPS())
+	//clear the new data flag so only data after the calibration counts
+	if _, err := d.readADC2Sample(); err != nil && !errors.Is(err, ErrChecksum) {
+		return err
+	}
+	if err := d.command(opcode); err != nil {
+		return err
+	}
+	//the chip writes the result to ADC2OFC or ADC2FSC itself
+	d.forget(ADC2OFC0_address, 4)
+	start := time.Now()
+	for time.Since(start) < 18*period+adc2CalibrationMargin {
+		time.Sleep(period / 2)
+		sample, err := d.readADC2Sample()
+		if err != nil {
+			if errors.Is(err, ErrChecksum) {
+				continue
+			}
+			return err
+		}
+		if sample.Status.ADC2NewData() {
+			return nil
+		}
+	}
+	return &TimeoutError{Waited: time.Since(start)}
+}
+
+//SelfOffsetCalibrateADC2 runs the ADC2 self offset calibration (SFOCAL2). ADC2 must be running. The inputs are shorted internally during calibration.
+func (d *Device) SelfOffsetCalibrateADC2() error {
+	return d.calibrateADC2(SFOCAL2)
+}
+
+//SystemOffsetCalibrateADC2 runs the ADC2 system offset calibration (SYOCAL2). ADC2 must be running and the inputs must be at the system zero point.
+func (d *Device) SystemOffsetCalibrateADC2() error {
+	return d.calibrateADC2(SYOCAL2)
+}
+
+//SystemGainCalibrateADC2 runs the ADC2 system gain calibration (SYGCAL2). ADC2 must be running and a full-scale signal must be applied to the inputs.
+func (d *Device) SystemGainCalibrateADC2() error {
+	return d.calibrateADC2(SYGCAL2)
+}
+
+//ReadADC2Calibration reads the ADC2 offset (ADC2OFC) and full-scale (ADC2FSC) calibration values
+func (d *Device) ReadADC2Calibration() (offset int16, fullscale uint16, err error) {
+	regs, err := d.ReadRegisters(ADC2OFC0_address, 4)
+	if err != nil {
+		return 0, 0, err
+	}
+	offset = int16(uint16(regs[1])<<8 | uint16(regs[0]))
+	fullscale = uint16(regs[3])<<8 | uint16(regs[2])
+	return offset, fullscale, nil
+}
+
+//WriteADC2Calibration writes the ADC2 offset (ADC2OFC) and full-scale (ADC2FSC) calibration values, for example to restore a known good calibration. A full-scale value of 4000h is a gain of one.
+func (d *Device) WriteADC2Calibration(offset int16, fullscale uint16) error {
+	return d.WriteRegisters(ADC2OFC0_address, []byte{byte(offset), byte(uint16(offset) >> 8), byte(fullscale), byte(fullscale >> 8)})
+}
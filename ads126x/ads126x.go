@@ -2,8 +2,6 @@
 package ads126x
 
 import (
-	"errors"
-	"log"
 	"math"
 	"time"
 
@@ -11,97 +9,78 @@ import (
 	"periph.io/x/periph/conn/spi"
 )
 
-var blank []byte
-
-func Startcommand(connection spi.Conn) {
-	if err := connection.Tx([]byte{START1}, blank); err != nil {
-		log.Fatal("spi failed")
-	}
+//Startcommand sends the START1 opcode to start ADC1 conversions
+func Startcommand(connection spi.Conn) error {
+	return New(connection, Pins{}).Start()
 }
 
-func Stopcommand(connection spi.Conn) {
-	if err := connection.Tx([]byte{STOP1}, blank); err != nil {
-		log.Fatal("spi failed")
-	}
+//Stopcommand sends the STOP1 opcode to stop ADC1 conversions
+func Stopcommand(connection spi.Conn) error {
+	return New(connection, Pins{}).Stop()
 }
 
 //funcs to write - read data, convert data, read pulse, startup, data to file
 
 //function to restart the ADS126x based on fig 159 from the datasheet
-func Restart(start, pwdn gpio.PinIO) {
-	if err := pwdn.Out(gpio.Low); err != nil {
-		log.Fatal(err)
+func Restart(start, pwdn gpio.PinIO) error {
+	if err := setPin("PWDN", pwdn, gpio.Low); err != nil {
+		return err
 	}
 
-	time.Sleep(500 * time.Millisecond)
-
-	pwdn.Out(gpio.High)
+	time.Sleep(resetPulse)
 
-	if err := start.Out(gpio.Low); err != nil {
-		log.Fatal(err)
+	if err := setPin("PWDN", pwdn, gpio.High); err != nil {
+		return err
 	}
 
-	time.Sleep(2 * time.Second)
+	if err := setPin("START", start, gpio.Low); err != nil {
+		return err
+	}
 
+	time.Sleep(resetSettle)
+	return nil
 }
 
 //alternative function to restart the ADS126x using the STOP1 command - Use this instead of InitSetup if you are not using the START pin. It is based on fig 159 from the datasheet. Make sure this comes after the SPI connection is initialized since it used to send the stop command
-func InitSetupNoStartPin(connection spi.Conn, start gpio.PinIO, pwdn gpio.PinIO) {
-	if err := pwdn.Out(gpio.Low); err != nil {
-		log.Fatal(err)
+func InitSetupNoStartPin(connection spi.Conn, start gpio.PinIO, pwdn gpio.PinIO) error {
+	if err := setPin("PWDN", pwdn, gpio.Low); err != nil {
+		return err
 	}
 
-	time.Sleep(500 * time.Millisecond)
-
-	pwdn.Out(gpio.High)
+	time.Sleep(resetPulse)
 
-	Stopcommand(connection)
+	if err := setPin("PWDN", pwdn, gpio.High); err != nil {
+		return err
+	}
 
-	time.Sleep(2 * time.Second)
+	if err := Stopcommand(connection); err != nil {
+		return err
+	}
 
+	time.Sleep(resetSettle)
+	return nil
 }
 
 //ContinuousReadCHK This function requires that the checksum be enabled in checksum mode and the status byte enabled. It reads the data in continuous mode - meaning that it waits for the data ready signal on the DRDY pin and then begins reading. The output is an unconverted 32 bit integer. If the checksum fails, SPI fails, or DRDY pin times out it will output an error and a value of zero.
+//
+//Deprecated: it creates a new Device with its read buffers on every call. Create a Device once with New and use its Read method instead.
 func ContinuousReadCHK(connection spi.Conn, drdy gpio.PinIO) (int32, error) {
-
-	if drdy.WaitForEdge(-1) {
-
-		if err := connection.Tx(empty, conversionbytes); err != nil {
-			return 0, errors.New("SPI connection failed")
-		} else if conversionbytes[5] != (conversionbytes[1]+conversionbytes[2]+conversionbytes[3]+conversionbytes[4]+0x9B)&255 {
-			return 0, errors.New("Checksum Failed - data transmission error occurred")
-		} else {
-			rawdata := int(conversionbytes[1])<<24 | int(conversionbytes[2])<<16 | int(conversionbytes[3])<<8 | int(conversionbytes[4])
-			tobeconverted := int32(rawdata)
-			return tobeconverted, nil
-		}
-	}
-	return 0, errors.New("Pin timeout")
+	d := New(connection, Pins{Drdy: drdy})
+	//the register settings aren't known here so keep waiting like this function always did
+	d.SetTimeout(-1)
+	return d.Read()
 }
 
+//ReadByCommandCHK reads the next new conversion data using the RDATA1 opcode. It requires that the checksum be enabled in checksum mode and the status byte enabled. If drdy is not nil it waits for the data ready signal first, otherwise it polls the status byte until there is new data.
+//
+//Deprecated: it creates a new Device with its read buffers on every call. Create a Device once with New and use its ReadNextByCommand method instead.
 func ReadByCommandCHK(connection spi.Conn, drdy gpio.PinIO) (int32, error) {
-	if err := connection.Tx(readcommand, commandconversionbytes); err != nil {
-		return 0, errors.New("SPI connection failed")
-	} else if commandconversionbytes[6] != (commandconversionbytes[2]+commandconversionbytes[3]+commandconversionbytes[4]+commandconversionbytes[5]+0x9B)&255 {
-		return 0, errors.New("Checksum Failed - data transmission error occurred")
-	} else {
-		rawdata := int(commandconversionbytes[2])<<24 | int(commandconversionbytes[3])<<16 | int(commandconversionbytes[4])<<8 | int(conversionbytes[5])
-		tobeconverted := int32(rawdata)
-		return tobeconverted, nil
-	}
-
+	sample, err := New(connection, Pins{Drdy: drdy}).ReadNextByCommand(-1)
+	return sample.Raw, err
 }
 
-//This converts the output of the read function to a voltage between -2.5 and 2.5. It does not account for gain.
+//This converts the output of the read function to a voltage between -2.5 and 2.5. It does not account for gain or an external reference - use Device.Volts or a Scale for that.
 func ConvertData(data int32) float64 {
 	converteddata := float64(data) * float64(2.5/math.Pow(2, 31))
 	return converteddata
 }
-
-var conversionbytes []byte = make([]byte, 6)
-var empty []byte = make([]byte, 6)
-var checksumfail bool = false
-
-var commandconversionbytes []byte = make([]byte, 7)
-
-var readcommand []byte = []byte{RDATA1, 0, 0, 0, 0, 0, 0}
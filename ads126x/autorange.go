@@ -0,0 +1,142 @@
+package ads126x
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+//Auto-ranging keeps the PGA gain as high as the signal allows. When the data gets close to full scale, or clips, or the PGA alarms go off, the gain is stepped down. When the data is small enough that twice the gain would still be well within range, the gain is stepped up. Each step writes MODE2, which restarts the conversion, and the conversions after it are thrown away until the filter has settled.
+
+//AutoRange sets how the gain is ranged. The gap between Up and Down is the hysteresis - Up must be less than half of Down so that stepping up one gain does not immediately call for stepping back down.
+type AutoRange struct {
+	//Min and Max are the lowest and highest gain to use
+	Min Gain
+	Max Gain
+	//Up is the fraction of full scale below which the gain is stepped up
+	Up float64
+	//Down is the fraction of full scale above which the gain is stepped down
+	Down float64
+	//Discard is the number of conversions thrown away after each gain change
+	Discard int
+}
+
+//DefaultAutoRange uses the whole gain range of the PGA, steps down above 80% of full scale and up below 20%, and throws away one conversion after each change
+var DefaultAutoRange = AutoRange{Min: Gain1, Max: Gain32, Up: 0.2, Down: 0.8, Discard: 1}
+
+//check returns an error if the settings can't work
+func (r AutoRange) check() error {
+	switch {
+	case r.Min > r.Max || r.Max > Gain32:
+		return fmt.Errorf("ads126x: auto-range gains %d to %d are not a valid range", r.Min.Factor(), r.Max.Factor())
+	case r.Down <= 0 || r.Down > 1:
+		return fmt.Errorf("ads126x: auto-range down threshold %v must be above 0 and at most 1", r.Down)
+	case r.Up < 0 || 2*r.Up >= r.Down:
+		return fmt.Errorf("ads126x: auto-range up threshold %v must be less than half the down threshold %v", r.Up, r.Down)
+	case r.Discard < 0:
+		return fmt.Errorf("ads126x: auto-range discard count %d is negative", r.Discard)
+	}
+	return nil
+}
+
+//RangedSample is a sample read while auto-ranging with the gain it was taken at
+type RangedSample struct {
+	Sample
+	Gain Gain
+}
+
+//AutoRanger reads ADC1 conversions and adjusts the PGA gain between them. Conversions must be running in continuous mode. Use NewAutoRanger to create one.
+type AutoRanger struct {
+	dev     *Device
+	config  AutoRange
+	gain    Gain
+	discard int
+}
+
+//NewAutoRanger creates an AutoRanger for the device. The PGA is enabled and the gain is kept from MODE2 if it is within the range, otherwise it is set to the nearest end of it.
+func NewAutoRanger(dev *Device, config AutoRange) (*AutoRanger, error) {
+	if err := config.check(); err != nil {
+		return nil, err
+	}
+	mode2 := dev.activeMode2()
+	gain := mode2.Gain
+	if gain < config.Min {
+		gain = config.Min
+	}
+	if gain > config.Max {
+		gain = config.Max
+	}
+	a := &AutoRanger{dev: dev, config: config, gain: mode2.Gain}
+	if mode2.Bypass || gain != mode2.Gain {
+		if err := a.setGain(gain); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+//Gain is the gain in use
+func (a *AutoRanger) Gain() Gain {
+	return a.gain
+}
+
+//setGain writes the gain to MODE2, keeping the data rate, and starts discarding the settling conversions
+func (a *AutoRanger) setGain(gain Gain) error {
+	mode2 := a.dev.activeMode2()
+	mode2.Bypass = false
+	mode2.Gain = gain
+	if err := a.dev.WriteConfig(mode2); err != nil {
+		return err
+	}
+	a.gain = gain
+	a.discard = a.config.Discard
+	return nil
+}
+
+//next works out the gain for the next conversion from a sample taken at the current gain
+func (a *AutoRanger) next(sample Sample) Gain {
+	if sample.Clipping != InRange {
+		if a.gain > a.config.Min {
+			return a.gain - 1
+		}
+		return a.gain
+	}
+	fraction := math.Abs(float64(sample.Raw)) / (1 << 31)
+	switch {
+	case fraction > a.config.Down && a.gain > a.config.Min:
+		return a.gain - 1
+	case fraction < a.config.Up && a.gain < a.config.Max:
+		return a.gain + 1
+	}
+	return a.gain
+}
+
+//Read reads the next settled conversion and adjusts the gain for the one after it. Clipped conversions are thrown away while a lower gain is left to try. Once at the lowest gain they are returned, marked in Clipping. It waits on DRDY if the device has a DRDY pin and polls the status byte otherwise.
+func (a *AutoRanger) Read(ctx context.Context) (RangedSample, error) {
+	read := a.dev.ReadContext
+	if a.dev.pins.Drdy == nil {
+		read = a.dev.ReadNextByCommandContext
+	}
+	for {
+		sample, err := read(ctx)
+		if err != nil && !errors.Is(err, ErrClipped) {
+			return RangedSample{}, err
+		}
+		if a.discard > 0 {
+			a.discard--
+			continue
+		}
+		ranged := RangedSample{Sample: sample, Gain: a.gain}
+		gain := a.next(sample)
+		if gain == a.gain {
+			return ranged, err
+		}
+		if err := a.setGain(gain); err != nil {
+			return RangedSample{}, err
+		}
+		if sample.Clipping == InRange {
+			return ranged, nil
+		}
+	}
+}
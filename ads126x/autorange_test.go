@@ -0,0 +1,62 @@
+package ads126x
+
+import "testing"
+
+func TestAutoRangerNext(t *testing.T) {
+	//fullScale is a fraction of ADC1 full scale as a code
+	fullScale := func(fraction float64) int32 { return int32(fraction * (1 << 31)) }
+	limited := AutoRange{Min: Gain2, Max: Gain16, Up: 0.2, Down: 0.8, Discard: 1}
+	tests := []struct {
+		name   string
+		config AutoRange
+		gain   Gain
+		sample Sample
+		want   Gain
+	}{
+		{"clipped positive steps down", DefaultAutoRange, Gain8, Sample{Raw: 0x7FFFFFFF, Clipping: ClippedPositive}, Gain4},
+		{"clipped negative steps down", DefaultAutoRange, Gain8, Sample{Raw: -0x80000000, Clipping: ClippedNegative}, Gain4},
+		{"PGA alarm steps down", DefaultAutoRange, Gain32, Sample{Raw: fullScale(0.1), Clipping: ClippedPositive}, Gain16},
+		{"clipped at gain 1 stays", DefaultAutoRange, Gain1, Sample{Raw: 0x7FFFFFFF, Clipping: ClippedPositive}, Gain1},
+		{"above the down threshold steps down", DefaultAutoRange, Gain4, Sample{Raw: fullScale(0.9)}, Gain2},
+		{"negative above the down threshold steps down", DefaultAutoRange, Gain4, Sample{Raw: -fullScale(0.9)}, Gain2},
+		{"above the down threshold at gain 1 stays", DefaultAutoRange, Gain1, Sample{Raw: fullScale(0.9)}, Gain1},
+		{"small signal steps up", DefaultAutoRange, Gain4, Sample{Raw: fullScale(0.1)}, Gain8},
+		{"small negative signal steps up", DefaultAutoRange, Gain4, Sample{Raw: -fullScale(0.1)}, Gain8},
+		{"small signal at gain 32 stays", DefaultAutoRange, Gain32, Sample{Raw: fullScale(0.01)}, Gain32},
+		{"zero at gain 32 stays", DefaultAutoRange, Gain32, Sample{}, Gain32},
+		{"between the thresholds stays", DefaultAutoRange, Gain4, Sample{Raw: fullScale(0.5)}, Gain4},
+		{"just above the up threshold stays", DefaultAutoRange, Gain4, Sample{Raw: fullScale(0.21)}, Gain4},
+		{"just below the down threshold stays", DefaultAutoRange, Gain4, Sample{Raw: fullScale(0.79)}, Gain4},
+		{"clipped at the lowest gain of the range stays", limited, Gain2, Sample{Raw: 0x7FFFFFFF, Clipping: ClippedPositive}, Gain2},
+		{"small signal at the highest gain of the range stays", limited, Gain16, Sample{Raw: fullScale(0.01)}, Gain16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AutoRanger{config: tt.config, gain: tt.gain}
+			if got := a.next(tt.sample); got != tt.want {
+				t.Errorf("next(%+v) at gain %d = gain %d, want gain %d", tt.sample, tt.gain.Factor(), got.Factor(), tt.want.Factor())
+			}
+		})
+	}
+}
+
+func TestAutoRangeCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AutoRange
+		wantErr bool
+	}{
+		{"default", DefaultAutoRange, false},
+		{"one gain", AutoRange{Min: Gain8, Max: Gain8, Up: 0.2, Down: 0.8}, false},
+		{"min above max", AutoRange{Min: Gain16, Max: Gain2, Up: 0.2, Down: 0.8}, true},
+		{"max above 32", AutoRange{Max: Gain32 + 1, Up: 0.2, Down: 0.8}, true},
+		{"down above 1", AutoRange{Max: Gain32, Up: 0.2, Down: 1.1}, true},
+		{"no hysteresis", AutoRange{Max: Gain32, Up: 0.4, Down: 0.8}, true},
+		{"negative discard", AutoRange{Max: Gain32, Up: 0.2, Down: 0.8, Discard: -1}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.config.check(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: check() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package ads126x
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//ADC1 calibration (see section 9.4.9 of the datasheet). The offset calibration value (OFCAL) is subtracted from the filter output and the result is multiplied by the full-scale calibration value (FSCAL) divided by 400000h. The calibration commands work these values out by averaging 16 conversions and write them to the registers, where they are lost on power down.
+
+//calibrationConversions is the number of conversions the ADC averages during calibration
+const calibrationConversions = 16
+
+//Calibration holds the ADC1 calibration registers
+type Calibration struct {
+	//Offset is the 24 bit two's complement offset calibration value (OFCAL0-2)
+	Offset int32
+	//FullScale is the 24 bit full-scale calibration value (FSCAL0-2). 400000h is a gain of one.
+	FullScale uint32
+}
+
+//DefaultCalibration is the calibration after a reset - no offset and a gain of one
+var DefaultCalibration = Calibration{Offset: 0, FullScale: 0x400000}
+
+//GainFactor is the factor the full-scale calibration multiplies the conversion data by
+func (c Calibration) GainFactor() float64 {
+	return float64(c.FullScale) / 0x400000
+}
+
+//bytes gives the six calibration register data bytes, OFCAL0 first
+func (c Calibration) bytes() ([]byte, error) {
+	if c.Offset < -0x800000 || c.Offset > 0x7FFFFF {
+		return nil, fmt.Errorf("ads126x: offset calibration %d does not fit in 24 bits", c.Offset)
+	}
+	if c.FullScale > 0xFFFFFF {
+		return nil, fmt.Errorf("ads126x: full-scale calibration %d does not fit in 24 bits", c.FullScale)
+	}
+	o := uint32(c.Offset)
+	return []byte{byte(o), byte(o >> 8), byte(o >> 16), byte(c.FullScale), byte(c.FullScale >> 8), byte(c.FullScale >> 16)}, nil
+}
+
+//calibrationFromBytes decodes the six calibration register data bytes, OFCAL0 first
+func calibrationFromBytes(regs []byte) Calibration {
+	return Calibration{
+		Offset:    signExtend24(regs[2], regs[1], regs[0]),
+		FullScale: uint32(regs[5])<<16 | uint32(regs[4])<<8 | uint32(regs[3]),
+	}
+}
+
+//ReadCalibration reads the ADC1 offset (OFCAL) and full-scale (FSCAL) calibration registers
+func (d *Device) ReadCalibration() (Calibration, error) {
+	regs, err := d.ReadRegisters(OFCAL0_address, 6)
+	if err != nil {
+		return Calibration{}, err
+	}
+	return calibrationFromBytes(regs), nil
+}
+
+//WriteCalibration writes the ADC1 offset (OFCAL) and full-scale (FSCAL) calibration registers, for example to restore a known good calibration
+func (d *Device) WriteCalibration(c Calibration) error {
+	data, err := c.bytes()
+	if err != nil {
+		return err
+	}
+	return d.WriteRegisters(OFCAL0_address, data)
+}
+
+//SelfOffsetCalibrate runs the ADC1 self offset calibration (SFOCAL1) and returns the resulting calibration. The inputs are disconnected and shorted internally, so nothing needs to be applied to them.
+func (d *Device) SelfOffsetCalibrate() (Calibration, error) {
+	return d.calibrate(SFOCAL1)
+}
+
+//SystemOffsetCalibrate runs the ADC1 system offset calibration (SYOCAL1) and returns the resulting calibration. The inputs must be at the system zero point (for example shorted at the sensor) while it runs.
+func (d *Device) SystemOffsetCalibrate() (Calibration, error) {
+	return d.calibrate(SYOCAL1)
+}
+
+//SystemGainCalibrate runs the ADC1 system gain calibration (SYGCAL1) and returns the resulting calibration. A full-scale signal must be applied to the inputs while it runs. Do the offset calibration first.
+func (d *Device) SystemGainCalibrate() (Calibration, error) {
+	return d.calibrate(SYGCAL1)
+}
+
+//calibrate starts conversions in continuous mode, waits for a settled conversion, sends the calibration opcode and waits for DRDY (or the new data bit when there is no DRDY pin) to signal that calibration is done. Conversions are stopped afterwards, the previous run mode is written back and the new calibration values are read back.
+func (d *Device) calibrate(opcode byte) (Calibration, error) {
+	var mode0 Mode0
+	if err := d.ReadConfig(&mode0); err != nil {
+		return Calibration{}, err
+	}
+	if err := d.SetRunMode(RunContinuous); err != nil {
+		return Calibration{}, err
+	}
+	err := d.Start()
+	if err == nil {
+		err = d.runCalibration(opcode)
+	}
+	if stoperr := d.Stop(); err == nil {
+		err = stoperr
+	}
+	if restoreerr := d.SetRunMode(mode0.RunMode); err == nil {
+		err = restoreerr
+	}
+	if err != nil {
+		return Calibration{}, err
+	}
+	return d.ReadCalibration()
+}
+
+func (d *Device) runCalibration(opcode byte) error {
+	ctx := context.Background()
+	//wait for a settled conversion so the calibration starts from settled inputs
+	if d.pins.Drdy != nil {
+		if err := d.waitDRDY(ctx, d.Timeout()); err != nil {
+			return err
+		}
+	} else if _, err := d.readNextByCommand(ctx, d.Timeout()); err != nil && !errors.Is(err, ErrChecksum) {
+		return err
+	}
+	if err := d.command(opcode); err != nil {
+		return err
+	}
+	//the chip writes the result to OFCAL or FSCAL itself
+	d.forget(OFCAL0_address, 6)
+	timeout := d.calibrationTimeout()
+	if d.pins.Drdy != nil {
+		return d.waitDRDY(ctx, timeout)
+	}
+	_, err := d.readNextByCommand(ctx, timeout)
+	if errors.Is(err, ErrChecksum) {
+		return nil
+	}
+	return err
+}
+
+//calibrationTimeout is long enough for the 16 conversions averaged during calibration on top of the normal timeout
+func (d *Device) calibrationTimeout() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	timeout := d.conversionTimeout()
+	if timeout < 0 {
+		return timeout
+	}
+	return timeout + time.Duration(calibrationConversions*float64(time.Second)/d.settling().Rate)
+}
@@ -0,0 +1,63 @@
+package ads126x
+
+import "testing"
+
+func TestCalibrateRestoresRunMode(t *testing.T) {
+	tests := []struct {
+		name string
+		mode RunMode
+	}{
+		{"pulse", RunPulse},
+		{"continuous", RunContinuous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeConn()
+			fake.regs[MODE0_address] = byte(tt.mode) << maskShift(MODE0_runmode_mask)
+			//status with new ADC1 data, zero data and its checksum
+			fake.frame = []byte{0x40, 0, 0, 0, 0, Checksum([]byte{0, 0, 0, 0})}
+			d := New(fake, Pins{})
+			if err := d.Sync(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := d.SelfOffsetCalibrate(); err != nil {
+				t.Fatal(err)
+			}
+			sent := false
+			for _, opcode := range fake.opcodes {
+				sent = sent || opcode == SFOCAL1
+			}
+			if !sent {
+				t.Errorf("SFOCAL1 was not sent")
+			}
+			if got := RunMode(getField(fake.regs[MODE0_address], MODE0_runmode_mask)); got != tt.mode {
+				t.Errorf("run mode is %d after calibration, want %d", got, tt.mode)
+			}
+		})
+	}
+}
+
+func TestCalibrationBytes(t *testing.T) {
+	tests := []struct {
+		c    Calibration
+		want []byte
+	}{
+		{DefaultCalibration, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x40}},
+		{Calibration{Offset: -2, FullScale: 0x123456}, []byte{0xFE, 0xFF, 0xFF, 0x56, 0x34, 0x12}},
+	}
+	for _, tt := range tests {
+		got, err := tt.c.bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(tt.want) {
+			t.Errorf("%+v bytes() = % X, want % X", tt.c, got, tt.want)
+		}
+		if back := calibrationFromBytes(got); back != tt.c {
+			t.Errorf("calibrationFromBytes(% X) = %+v, want %+v", got, back, tt.c)
+		}
+	}
+	if _, err := (Calibration{Offset: 0x800000}).bytes(); err == nil {
+		t.Errorf("an offset that doesn't fit in 24 bits was accepted")
+	}
+}
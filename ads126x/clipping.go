@@ -0,0 +1,96 @@
+package ads126x
+
+import "fmt"
+
+//When the input is beyond full scale the ADC clips the conversion data to the largest positive or negative code (7FFFFFFFh or 80000000h for ADC1, 7FFFFFh or 800000h for ADC2). An overloaded PGA also sets the PGA alarm bits in the status byte, usually before the data clips. Either way the data no longer follows the input.
+
+//Clipping says if a conversion result is within the range of the ADC
+type Clipping byte
+
+const (
+	InRange Clipping = iota
+	//ClippedPositive means the input was above positive full scale
+	ClippedPositive
+	//ClippedNegative means the input was below negative full scale
+	ClippedNegative
+)
+
+func (c Clipping) String() string {
+	switch c {
+	case InRange:
+		return "in-range"
+	case ClippedPositive:
+		return "clipped-positive"
+	case ClippedNegative:
+		return "clipped-negative"
+	}
+	return fmt.Sprintf("Clipping(%d)", byte(c))
+}
+
+//ADC1 and ADC2 clip to these codes. ADC2 codes are sign extended.
+const (
+	adc1PositiveClip int32 = 0x7FFFFFFF
+	adc1NegativeClip int32 = -0x80000000
+	adc2PositiveClip int32 = 0x7FFFFF
+	adc2NegativeClip int32 = -0x800000
+)
+
+//ClippingOf works out the clipping of ADC1 conversion data from the code alone, for data read without the status byte
+func ClippingOf(code int32) Clipping {
+	return clippingOf(code, adc1PositiveClip, adc1NegativeClip)
+}
+
+func clippingOf(code, positive, negative int32) Clipping {
+	switch code {
+	case positive:
+		return ClippedPositive
+	case negative:
+		return ClippedNegative
+	}
+	return InRange
+}
+
+//adc1Clipping works out the clipping of an ADC1 sample from the code and the PGA alarms. The alarms don't say which way the PGA is overloaded so the sign of the data decides.
+func adc1Clipping(code int32, status Status) Clipping {
+	if c := ClippingOf(code); c != InRange {
+		return c
+	}
+	if status.PGALow() || status.PGAHigh() || status.PGADifferential() {
+		if code < 0 {
+			return ClippedNegative
+		}
+		return ClippedPositive
+	}
+	return InRange
+}
+
+//ClipPolicy sets what reads do with clipped samples
+type ClipPolicy byte
+
+const (
+	//ClipFlag returns clipped samples like any other, marked in Sample.Clipping. This is the default.
+	ClipFlag ClipPolicy = iota
+	//ClipDrop returns clipped samples together with an error matching ErrClipped so they can be thrown away. Stream skips them.
+	ClipDrop
+)
+
+//SetClipPolicy sets what the read functions do with clipped samples
+func (d *Device) SetClipPolicy(policy ClipPolicy) {
+	d.mu.Lock()
+	d.clippolicy = policy
+	d.mu.Unlock()
+}
+
+//applyClipPolicy turns a clipped sample into an ErrClipped error when the policy is ClipDrop
+func (d *Device) applyClipPolicy(sample Sample, err error) (Sample, error) {
+	if err != nil || sample.Clipping == InRange {
+		return sample, err
+	}
+	d.mu.Lock()
+	policy := d.clippolicy
+	d.mu.Unlock()
+	if policy == ClipDrop {
+		return sample, fmt.Errorf("%w: %v", ErrClipped, sample.Clipping)
+	}
+	return sample, nil
+}
@@ -0,0 +1,51 @@
+package ads126x
+
+import "testing"
+
+func TestADC1Clipping(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   int32
+		status Status
+		want   Clipping
+	}{
+		{"in range", 0x12345678, 0x40, InRange},
+		{"zero", 0, 0x40, InRange},
+		{"positive rail", 0x7FFFFFFF, 0x40, ClippedPositive},
+		{"negative rail", -0x80000000, 0x40, ClippedNegative},
+		{"rail without status", 0x7FFFFFFF, 0, ClippedPositive},
+		{"one below the rail", 0x7FFFFFFE, 0x40, InRange},
+		{"PGA high alarm", 0x12345678, Status(0x40 | STATUS_PGAH_ALM), ClippedPositive},
+		{"PGA low alarm with negative data", -0x12345678, Status(0x40 | STATUS_PGAL_ALM), ClippedNegative},
+		//the alarms don't say which way the PGA is overloaded, the sign of the data does
+		{"PGA low alarm with positive data", 0x12345678, Status(0x40 | STATUS_PGAL_ALM), ClippedPositive},
+		{"PGA differential alarm", -1, Status(0x40 | STATUS_PGAD_ALM), ClippedNegative},
+		//the rail wins over the alarm
+		{"rail and alarm", -0x80000000, Status(0x40 | STATUS_PGAH_ALM), ClippedNegative},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adc1Clipping(tt.code, tt.status); got != tt.want {
+				t.Errorf("adc1Clipping(%d, 0x%02X) = %v, want %v", tt.code, byte(tt.status), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClippingOfADC2(t *testing.T) {
+	tests := []struct {
+		code int32
+		want Clipping
+	}{
+		{0x7FFFFF, ClippedPositive},
+		{-0x800000, ClippedNegative},
+		{0x7FFFFE, InRange},
+		//an ADC1 rail is not an ADC2 rail
+		{0x7FFFFFFF, InRange},
+	}
+	for _, tt := range tests {
+		if got := clippingOf(tt.code, adc2PositiveClip, adc2NegativeClip); got != tt.want {
+			t.Errorf("ADC2 clipping of %d = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
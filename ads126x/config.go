@@ -0,0 +1,748 @@
+package ads126x
+
+import (
+	"fmt"
+	"time"
+)
+
+//The types in this file are typed versions of the register settings in the constants file. Each register has a struct with one field per setting so two options for the same setting can never be combined by mistake. Encode turns a struct into the byte to write to the register and Decode does the opposite. Note that the zero value of a struct is not always the register's default - decode the *_default constant to get that.
+
+//RegisterConfig is a typed register setting that can be written to the ADC
+type RegisterConfig interface {
+	//Address is the address of the register
+	Address() byte
+	//Encode gives the register data byte or an error if a setting is out of range
+	Encode() (byte, error)
+}
+
+//RegisterReader is a typed register setting that can be filled in from a register data byte. Pointers to the config structs in this file implement it.
+type RegisterReader interface {
+	Address() byte
+	Decode(value byte) error
+}
+
+//fieldError is returned when a setting does not fit its field or a register byte holds a value the datasheet marks as reserved
+func fieldError(register, field string, value byte) error {
+	return fmt.Errorf("ads126x: invalid %s %s setting %d", register, field, value)
+}
+
+//setField shifts value into the field given by mask and checks it fits
+func setField(register, field string, mask, value byte) (byte, error) {
+	shift := maskShift(mask)
+	if value > mask>>shift {
+		return 0, fieldError(register, field, value)
+	}
+	return value << shift, nil
+}
+
+//getField extracts the field given by mask from a register data byte
+func getField(reg, mask byte) byte {
+	return (reg & mask) >> maskShift(mask)
+}
+
+//maskShift is the position of the lowest bit set in the mask
+func maskShift(mask byte) uint {
+	var shift uint
+	for mask != 0 && mask&1 == 0 {
+		mask >>= 1
+		shift++
+	}
+	return shift
+}
+
+func boolBit(b bool, mask byte) byte {
+	if b {
+		return mask
+	}
+	return 0
+}
+
+//BuildRegister combines setting constants from the constants file into a register data byte, like Register.Setregister does, but it returns an error if two settings set the same field to different values (for example MODE2_DR_20 and MODE2_DR_400) or if a setting touches bits that do not belong to the register. Settings whose value is zero (like MODE2_GAIN_1) cannot be told apart from an unset field, so they never conflict - use the typed config structs to rule that out as well.
+func BuildRegister(address byte, settings ...byte) (byte, error) {
+	fields, ok := registerFields[address]
+	if !ok {
+		return 0, fmt.Errorf("ads126x: register 0x%02X has no settings", address)
+	}
+	var all byte
+	for _, f := range fields {
+		all |= f.mask
+	}
+	var value byte
+	for _, setting := range settings {
+		if setting&^all != 0 {
+			return 0, fmt.Errorf("ads126x: setting 0b%08b sets reserved bits of register 0x%02X", setting, address)
+		}
+		for _, f := range fields {
+			if setting&f.mask != 0 && value&f.mask != 0 && setting&f.mask != value&f.mask {
+				return 0, fmt.Errorf("ads126x: conflicting %s settings 0b%08b and 0b%08b for register 0x%02X", f.name, value&f.mask, setting&f.mask, address)
+			}
+		}
+		value |= setting
+	}
+	return value, nil
+}
+
+type registerField struct {
+	name string
+	mask byte
+}
+
+//registerFields lists the fields of every register that has settings
+var registerFields = map[byte][]registerField{
+	POWER_address:     {{"reset", POWER_reset_mask}, {"vbias", POWER_vbias_mask}, {"intref", POWER_intref_mask}},
+	INTERFACE_address: {{"timeout", INTERFACE_timeout_mask}, {"status", INTERFACE_status_mask}, {"crc", INTERFACE_crc_mask}},
+	MODE0_address:     {{"refrev", MODE0_refrev_mask}, {"runmode", MODE0_runmode_mask}, {"chop", MODE0_chop_mask}, {"delay", MODE0_delay_mask}},
+	MODE1_address:     {{"filter", MODE1_filter_mask}, {"sbADC", MODE1_sbADC_mask}, {"sbpol", MODE1_sbpol_mask}, {"sbmag", MODE1_sbmag_mask}},
+	MODE2_address:     {{"bypass", MODE2_bypass_mask}, {"gain", MODE2_GAIN_mask}, {"data rate", MODE2_DR_mask}},
+	INPMUX_address:    {{"muxP", INPMUX_muxP_mask}, {"muxN", INPMUX_muxN_mask}},
+	IDACMUX_address:   {{"mux2", IDACMUX_mux2_mask}, {"mux1", IDACMUX_mux1_mask}},
+	IDACMAG_address:   {{"mag2", IDACMAG_mag2_mask}, {"mag1", IDACMAG_mag1_mask}},
+	REFMUX_address:    {{"rmuxP", REFMUX_rmuxP_mask}, {"rmuxN", REFMUX_rmuxN_mask}},
+	TDACP_address:     {{"outP", TDACP_outP_mask}, {"magP", TDACP_magP_mask}},
+	TDACN_address:     {{"outN", TDACN_outN_mask}, {"magN", TDACN_magN_mask}},
+	ADC2CFG_address:   {{"DR2", ADC2CFG_DR2_mask}, {"REF2", ADC2CFG_REF2_mask}, {"GAIN2", ADC2CFG_GAIN2_mask}},
+	ADC2MUX_address:   {{"muxP2", ADC2MUX_muxP2_mask}, {"muxN2", ADC2MUX_muxN2_mask}},
+}
+
+//PowerConfig is the POWER register
+type PowerConfig struct {
+	//Reset is the reset indicator. It is set by the chip after a reset - write false to clear it so the next reset can be detected.
+	Reset bool
+	//VBias enables the level shift voltage on AINCOM
+	VBias bool
+	//InternalRef enables the 2.5 V internal reference. The IDACs and the temperature sensor need it.
+	InternalRef bool
+}
+
+func (PowerConfig) Address() byte { return POWER_address }
+
+func (c PowerConfig) Encode() (byte, error) {
+	return boolBit(c.Reset, POWER_reset_mask) | boolBit(c.VBias, POWER_vbias_mask) | boolBit(c.InternalRef, POWER_intref_mask), nil
+}
+
+func (c *PowerConfig) Decode(value byte) error {
+	c.Reset = value&POWER_reset_mask != 0
+	c.VBias = value&POWER_vbias_mask != 0
+	c.InternalRef = value&POWER_intref_mask != 0
+	return nil
+}
+
+//CheckMode selects the check byte sent with conversion data
+type CheckMode byte
+
+const (
+	CheckDisabled CheckMode = iota
+	CheckChecksum
+	CheckCRC
+)
+
+//InterfaceConfig is the INTERFACE register
+type InterfaceConfig struct {
+	//Timeout enables the serial interface automatic time-out mode
+	Timeout bool
+	//Status enables the status byte during conversion data read-back
+	Status bool
+	//Check selects the checksum or CRC byte during conversion data read-back
+	Check CheckMode
+}
+
+func (InterfaceConfig) Address() byte { return INTERFACE_address }
+
+func (c InterfaceConfig) Encode() (byte, error) {
+	if c.Check > CheckCRC {
+		return 0, fieldError("INTERFACE", "crc", byte(c.Check))
+	}
+	return boolBit(c.Timeout, INTERFACE_timeout_mask) | boolBit(c.Status, INTERFACE_status_mask) | byte(c.Check), nil
+}
+
+func (c *InterfaceConfig) Decode(value byte) error {
+	check := CheckMode(getField(value, INTERFACE_crc_mask))
+	if check > CheckCRC {
+		return fieldError("INTERFACE", "crc", byte(check))
+	}
+	c.Timeout = value&INTERFACE_timeout_mask != 0
+	c.Status = value&INTERFACE_status_mask != 0
+	c.Check = check
+	return nil
+}
+
+//RunMode selects continuous or one shot (pulse) conversions
+type RunMode byte
+
+const (
+	RunContinuous RunMode = iota
+	RunPulse
+)
+
+//ChopMode selects input chop and IDAC rotation
+type ChopMode byte
+
+const (
+	ChopDisabled ChopMode = iota
+	ChopInput
+	ChopIDACRotation
+	ChopInputAndIDACRotation
+)
+
+//ConversionDelay is the extra delay from conversion start to the beginning of the actual conversion
+type ConversionDelay byte
+
+const (
+	DelayNone ConversionDelay = iota
+	Delay8_7us
+	Delay17us
+	Delay35us
+	Delay69us
+	Delay139us
+	Delay278us
+	Delay555us
+	Delay1_1ms
+	Delay2_2ms
+	Delay4_4ms
+	Delay8_8ms
+)
+
+var conversionDelays = [...]time.Duration{0, 8700 * time.Nanosecond, 17 * time.Microsecond, 35 * time.Microsecond, 69 * time.Microsecond, 139 * time.Microsecond, 278 * time.Microsecond, 555 * time.Microsecond, 1100 * time.Microsecond, 2200 * time.Microsecond, 4400 * time.Microsecond, 8800 * time.Microsecond}
+
+//Duration is the length of the delay
+func (c ConversionDelay) Duration() time.Duration {
+	if int(c) >= len(conversionDelays) {
+		return 0
+	}
+	return conversionDelays[c]
+}
+
+//Mode0 is the MODE0 register
+type Mode0 struct {
+	//RefReverse reverses the ADC1 reference multiplexer output polarity
+	RefReverse bool
+	RunMode    RunMode
+	Chop       ChopMode
+	Delay      ConversionDelay
+}
+
+func (Mode0) Address() byte { return MODE0_address }
+
+func (c Mode0) Encode() (byte, error) {
+	runmode, err := setField("MODE0", "runmode", MODE0_runmode_mask, byte(c.RunMode))
+	if err != nil {
+		return 0, err
+	}
+	chop, err := setField("MODE0", "chop", MODE0_chop_mask, byte(c.Chop))
+	if err != nil {
+		return 0, err
+	}
+	if c.Delay > Delay8_8ms {
+		return 0, fieldError("MODE0", "delay", byte(c.Delay))
+	}
+	return boolBit(c.RefReverse, MODE0_refrev_mask) | runmode | chop | byte(c.Delay), nil
+}
+
+func (c *Mode0) Decode(value byte) error {
+	delay := ConversionDelay(getField(value, MODE0_delay_mask))
+	if delay > Delay8_8ms {
+		return fieldError("MODE0", "delay", byte(delay))
+	}
+	c.RefReverse = value&MODE0_refrev_mask != 0
+	c.RunMode = RunMode(getField(value, MODE0_runmode_mask))
+	c.Chop = ChopMode(getField(value, MODE0_chop_mask))
+	c.Delay = delay
+	return nil
+}
+
+//Filter selects the ADC digital filter
+type Filter byte
+
+const (
+	FilterSinc1 Filter = iota
+	FilterSinc2
+	FilterSinc3
+	FilterSinc4
+	FilterFIR
+)
+
+//SensorBiasADC selects the ADC the sensor bias is connected to
+type SensorBiasADC byte
+
+const (
+	SensorBiasADC1 SensorBiasADC = iota
+	SensorBiasADC2
+)
+
+//SensorBiasPolarity selects sensor bias pull-up or pull-down mode
+type SensorBiasPolarity byte
+
+const (
+	//AINP pulled high, AINN pulled low
+	SensorBiasPullUp SensorBiasPolarity = iota
+	//AINP pulled low, AINN pulled high
+	SensorBiasPullDown
+)
+
+//SensorBiasMagnitude selects the sensor bias current or resistor
+type SensorBiasMagnitude byte
+
+const (
+	SensorBiasNone SensorBiasMagnitude = iota
+	SensorBias500nA
+	SensorBias2uA
+	SensorBias10uA
+	SensorBias50uA
+	SensorBias200uA
+	SensorBias10MOhm
+)
+
+//Mode1 is the MODE1 register
+type Mode1 struct {
+	Filter        Filter
+	SensorBiasADC SensorBiasADC
+	SensorBiasPol SensorBiasPolarity
+	SensorBiasMag SensorBiasMagnitude
+}
+
+func (Mode1) Address() byte { return MODE1_address }
+
+func (c Mode1) Encode() (byte, error) {
+	if c.Filter > FilterFIR {
+		return 0, fieldError("MODE1", "filter", byte(c.Filter))
+	}
+	filter, _ := setField("MODE1", "filter", MODE1_filter_mask, byte(c.Filter))
+	sbadc, err := setField("MODE1", "sbADC", MODE1_sbADC_mask, byte(c.SensorBiasADC))
+	if err != nil {
+		return 0, err
+	}
+	sbpol, err := setField("MODE1", "sbpol", MODE1_sbpol_mask, byte(c.SensorBiasPol))
+	if err != nil {
+		return 0, err
+	}
+	if c.SensorBiasMag > SensorBias10MOhm {
+		return 0, fieldError("MODE1", "sbmag", byte(c.SensorBiasMag))
+	}
+	return filter | sbadc | sbpol | byte(c.SensorBiasMag), nil
+}
+
+func (c *Mode1) Decode(value byte) error {
+	filter := Filter(getField(value, MODE1_filter_mask))
+	if filter > FilterFIR {
+		return fieldError("MODE1", "filter", byte(filter))
+	}
+	sbmag := SensorBiasMagnitude(getField(value, MODE1_sbmag_mask))
+	if sbmag > SensorBias10MOhm {
+		return fieldError("MODE1", "sbmag", byte(sbmag))
+	}
+	c.Filter = filter
+	c.SensorBiasADC = SensorBiasADC(getField(value, MODE1_sbADC_mask))
+	c.SensorBiasPol = SensorBiasPolarity(getField(value, MODE1_sbpol_mask))
+	c.SensorBiasMag = sbmag
+	return nil
+}
+
+//Gain is the PGA gain
+type Gain byte
+
+const (
+	Gain1 Gain = iota
+	Gain2
+	Gain4
+	Gain8
+	Gain16
+	Gain32
+)
+
+//Factor is the gain in V/V
+func (g Gain) Factor() int {
+	return 1 << g
+}
+
+//DataRate is the ADC1 data rate
+type DataRate byte
+
+const (
+	Rate2_5 DataRate = iota
+	Rate5
+	Rate10
+	Rate16_6
+	Rate20
+	Rate50
+	Rate60
+	Rate100
+	Rate400
+	Rate1200
+	Rate2400
+	Rate4800
+	Rate7200
+	Rate14400
+	Rate19200
+	Rate38400
+)
+
+var dataRateSPS = [...]float64{2.5, 5, 10, 16.6666667, 20, 50, 60, 100, 400, 1200, 2400, 4800, 7200, 14400, 19200, 38400}
+
+//SPS is the nominal data rate in samples per second
+func (r DataRate) SPS() float64 {
+	if int(r) >= len(dataRateSPS) {
+		return 0
+	}
+	return dataRateSPS[r]
+}
+
+//Mode2 is the MODE2 register
+type Mode2 struct {
+	//Bypass bypasses the PGA. The gain must then be 1.
+	Bypass bool
+	Gain   Gain
+	Rate   DataRate
+}
+
+func (Mode2) Address() byte { return MODE2_address }
+
+func (c Mode2) Encode() (byte, error) {
+	if c.Gain > Gain32 {
+		return 0, fieldError("MODE2", "gain", byte(c.Gain))
+	}
+	gain, _ := setField("MODE2", "gain", MODE2_GAIN_mask, byte(c.Gain))
+	rate, err := setField("MODE2", "data rate", MODE2_DR_mask, byte(c.Rate))
+	if err != nil {
+		return 0, err
+	}
+	return boolBit(c.Bypass, MODE2_bypass_mask) | gain | rate, nil
+}
+
+func (c *Mode2) Decode(value byte) error {
+	gain := Gain(getField(value, MODE2_GAIN_mask))
+	if gain > Gain32 {
+		return fieldError("MODE2", "gain", byte(gain))
+	}
+	c.Bypass = value&MODE2_bypass_mask != 0
+	c.Gain = gain
+	c.Rate = DataRate(getField(value, MODE2_DR_mask))
+	return nil
+}
+
+//Input is an input multiplexer selection
+type Input byte
+
+const (
+	AIN0 Input = iota
+	AIN1
+	AIN2
+	AIN3
+	AIN4
+	AIN5
+	AIN6
+	AIN7
+	AIN8
+	AIN9
+	AINCOM
+	//temperature sensor monitor
+	TempSensor
+	//analog power supply monitor
+	AnalogSupply
+	//digital power supply monitor
+	DigitalSupply
+	//TDAC test signal
+	TDACTest
+	//open connection
+	Float
+)
+
+var inputNames = [...]string{"AIN0", "AIN1", "AIN2", "AIN3", "AIN4", "AIN5", "AIN6", "AIN7", "AIN8", "AIN9", "AINCOM", "TempSensor", "AnalogSupply", "DigitalSupply", "TDACTest", "Float"}
+
+func (i Input) String() string {
+	if int(i) >= len(inputNames) {
+		return fmt.Sprintf("Input(%d)", byte(i))
+	}
+	return inputNames[i]
+}
+
+//InpMux is the INPMUX register which selects the ADC1 inputs
+type InpMux struct {
+	Positive Input
+	Negative Input
+}
+
+func (InpMux) Address() byte { return INPMUX_address }
+
+func (c InpMux) Encode() (byte, error) {
+	p, err := setField("INPMUX", "muxP", INPMUX_muxP_mask, byte(c.Positive))
+	if err != nil {
+		return 0, err
+	}
+	n, err := setField("INPMUX", "muxN", INPMUX_muxN_mask, byte(c.Negative))
+	if err != nil {
+		return 0, err
+	}
+	return p | n, nil
+}
+
+func (c *InpMux) Decode(value byte) error {
+	c.Positive = Input(getField(value, INPMUX_muxP_mask))
+	c.Negative = Input(getField(value, INPMUX_muxN_mask))
+	return nil
+}
+
+//idacNone is the IDACMUX code for no connection
+const idacNone byte = 0b1011
+
+//IDACMux is the IDACMUX register which connects the IDACs to analog input pins. Only AIN0 to AIN9, AINCOM and Float (no connection) can be used.
+type IDACMux struct {
+	IDAC1 Input
+	IDAC2 Input
+}
+
+func (IDACMux) Address() byte { return IDACMUX_address }
+
+func idacPin(field string, i Input) (byte, error) {
+	switch {
+	case i <= AINCOM:
+		return byte(i), nil
+	case i == Float:
+		return idacNone, nil
+	}
+	return 0, fieldError("IDACMUX", field, byte(i))
+}
+
+func idacInput(field string, code byte) (Input, error) {
+	switch {
+	case code <= byte(AINCOM):
+		return Input(code), nil
+	case code == idacNone:
+		return Float, nil
+	}
+	return 0, fieldError("IDACMUX", field, code)
+}
+
+func (c IDACMux) Encode() (byte, error) {
+	mux1, err := idacPin("mux1", c.IDAC1)
+	if err != nil {
+		return 0, err
+	}
+	mux2, err := idacPin("mux2", c.IDAC2)
+	if err != nil {
+		return 0, err
+	}
+	return mux2<<4 | mux1, nil
+}
+
+func (c *IDACMux) Decode(value byte) error {
+	idac1, err := idacInput("mux1", getField(value, IDACMUX_mux1_mask))
+	if err != nil {
+		return err
+	}
+	idac2, err := idacInput("mux2", getField(value, IDACMUX_mux2_mask))
+	if err != nil {
+		return err
+	}
+	c.IDAC1, c.IDAC2 = idac1, idac2
+	return nil
+}
+
+//IDACCurrent is the output current of an IDAC
+type IDACCurrent byte
+
+const (
+	IDACOff IDACCurrent = iota
+	IDAC50uA
+	IDAC100uA
+	IDAC250uA
+	IDAC500uA
+	IDAC750uA
+	IDAC1000uA
+	IDAC1500uA
+	IDAC2000uA
+	IDAC2500uA
+	IDAC3000uA
+)
+
+var idacMicroamps = [...]float64{0, 50, 100, 250, 500, 750, 1000, 1500, 2000, 2500, 3000}
+
+//Microamps is the nominal current in µA
+func (c IDACCurrent) Microamps() float64 {
+	if int(c) >= len(idacMicroamps) {
+		return 0
+	}
+	return idacMicroamps[c]
+}
+
+//IDACMag is the IDACMAG register which sets the IDAC currents
+type IDACMag struct {
+	IDAC1 IDACCurrent
+	IDAC2 IDACCurrent
+}
+
+func (IDACMag) Address() byte { return IDACMAG_address }
+
+func (c IDACMag) Encode() (byte, error) {
+	if c.IDAC1 > IDAC3000uA {
+		return 0, fieldError("IDACMAG", "mag1", byte(c.IDAC1))
+	}
+	if c.IDAC2 > IDAC3000uA {
+		return 0, fieldError("IDACMAG", "mag2", byte(c.IDAC2))
+	}
+	return byte(c.IDAC2)<<4 | byte(c.IDAC1), nil
+}
+
+func (c *IDACMag) Decode(value byte) error {
+	mag1 := IDACCurrent(getField(value, IDACMAG_mag1_mask))
+	if mag1 > IDAC3000uA {
+		return fieldError("IDACMAG", "mag1", byte(mag1))
+	}
+	mag2 := IDACCurrent(getField(value, IDACMAG_mag2_mask))
+	if mag2 > IDAC3000uA {
+		return fieldError("IDACMAG", "mag2", byte(mag2))
+	}
+	c.IDAC1, c.IDAC2 = mag1, mag2
+	return nil
+}
+
+//RefP selects the positive reference input
+type RefP byte
+
+const (
+	RefPInternal RefP = iota
+	RefPAIN0
+	RefPAIN2
+	RefPAIN4
+	RefPAVDD
+)
+
+//RefN selects the negative reference input
+type RefN byte
+
+const (
+	RefNInternal RefN = iota
+	RefNAIN1
+	RefNAIN3
+	RefNAIN5
+	RefNAVSS
+)
+
+//RefMux is the REFMUX register which selects the ADC1 reference inputs
+type RefMux struct {
+	Positive RefP
+	Negative RefN
+}
+
+func (RefMux) Address() byte { return REFMUX_address }
+
+func (c RefMux) Encode() (byte, error) {
+	if c.Positive > RefPAVDD {
+		return 0, fieldError("REFMUX", "rmuxP", byte(c.Positive))
+	}
+	if c.Negative > RefNAVSS {
+		return 0, fieldError("REFMUX", "rmuxN", byte(c.Negative))
+	}
+	p, _ := setField("REFMUX", "rmuxP", REFMUX_rmuxP_mask, byte(c.Positive))
+	return p | byte(c.Negative), nil
+}
+
+func (c *RefMux) Decode(value byte) error {
+	p := RefP(getField(value, REFMUX_rmuxP_mask))
+	if p > RefPAVDD {
+		return fieldError("REFMUX", "rmuxP", byte(p))
+	}
+	n := RefN(getField(value, REFMUX_rmuxN_mask))
+	if n > RefNAVSS {
+		return fieldError("REFMUX", "rmuxN", byte(n))
+	}
+	c.Positive, c.Negative = p, n
+	return nil
+}
+
+//TDACMagnitude is the output voltage of a test DAC with respect to VAVSS
+type TDACMagnitude byte
+
+const (
+	TDAC2_5       TDACMagnitude = 0b00000
+	TDAC2_5078125 TDACMagnitude = 0b00001
+	TDAC2_515625  TDACMagnitude = 0b00010
+	TDAC2_53125   TDACMagnitude = 0b00011
+	TDAC2_5625    TDACMagnitude = 0b00100
+	TDAC2_625     TDACMagnitude = 0b00101
+	TDAC2_75      TDACMagnitude = 0b00110
+	TDAC3         TDACMagnitude = 0b00111
+	TDAC3_5       TDACMagnitude = 0b01000
+	TDAC4_5       TDACMagnitude = 0b01001
+	TDAC2_4921875 TDACMagnitude = 0b10001
+	TDAC2_484375  TDACMagnitude = 0b10010
+	TDAC2_46875   TDACMagnitude = 0b10011
+	TDAC2_4375    TDACMagnitude = 0b10100
+	TDAC2_375     TDACMagnitude = 0b10101
+	TDAC2_25      TDACMagnitude = 0b10110
+	TDAC2         TDACMagnitude = 0b10111
+	TDAC1_5       TDACMagnitude = 0b11000
+	TDAC0_5       TDACMagnitude = 0b11001
+)
+
+var tdacVolts = map[TDACMagnitude]float64{
+	TDAC2_5: 2.5, TDAC2_5078125: 2.5078125, TDAC2_515625: 2.515625, TDAC2_53125: 2.53125, TDAC2_5625: 2.5625,
+	TDAC2_625: 2.625, TDAC2_75: 2.75, TDAC3: 3, TDAC3_5: 3.5, TDAC4_5: 4.5,
+	TDAC2_4921875: 2.4921875, TDAC2_484375: 2.484375, TDAC2_46875: 2.46875, TDAC2_4375: 2.4375, TDAC2_375: 2.375,
+	TDAC2_25: 2.25, TDAC2: 2, TDAC1_5: 1.5, TDAC0_5: 0.5,
+}
+
+//Volts is the nominal output voltage with respect to VAVSS
+func (m TDACMagnitude) Volts() float64 {
+	return tdacVolts[m]
+}
+
+//TDAC is the TDACP register or, if Negative is set, the TDACN register
+type TDAC struct {
+	Negative bool
+	//Output connects the test DAC to AIN6 (TDACP) or AIN7 (TDACN)
+	Output    bool
+	Magnitude TDACMagnitude
+}
+
+func (c TDAC) Address() byte {
+	if c.Negative {
+		return TDACN_address
+	}
+	return TDACP_address
+}
+
+func (c TDAC) Encode() (byte, error) {
+	if _, ok := tdacVolts[c.Magnitude]; !ok {
+		return 0, fieldError("TDAC", "magnitude", byte(c.Magnitude))
+	}
+	return boolBit(c.Output, TDACP_outP_mask) | byte(c.Magnitude), nil
+}
+
+//Decode fills in Output and Magnitude. Negative is left as it is since it is given by the register address.
+func (c *TDAC) Decode(value byte) error {
+	mag := TDACMagnitude(getField(value, TDACP_magP_mask))
+	if _, ok := tdacVolts[mag]; !ok {
+		return fieldError("TDAC", "magnitude", byte(mag))
+	}
+	c.Output = value&TDACP_outP_mask != 0
+	c.Magnitude = mag
+	return nil
+}
+
+//WriteConfig encodes the given register settings and writes them to the ADC
+func (d *Device) WriteConfig(configs ...RegisterConfig) error {
+	for _, c := range configs {
+		value, err := c.Encode()
+		if err != nil {
+			return err
+		}
+		if err := d.WriteRegisters(c.Address(), []byte{value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//ReadConfig reads the registers of the given settings from the ADC and decodes them
+func (d *Device) ReadConfig(configs ...RegisterReader) error {
+	for _, c := range configs {
+		value, err := d.ReadRegisters(c.Address(), 1)
+		if err != nil {
+			return err
+		}
+		if err := c.Decode(value[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
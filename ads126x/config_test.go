@@ -0,0 +1,160 @@
+package ads126x
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegisterConfigRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RegisterConfig
+		decoded RegisterReader
+		want    byte
+	}{
+		{"POWER default", PowerConfig{Reset: true, InternalRef: true}, &PowerConfig{}, POWER_default},
+		{"POWER vbias", PowerConfig{VBias: true}, &PowerConfig{}, 0x02},
+		{"INTERFACE default", InterfaceConfig{Status: true, Check: CheckChecksum}, &InterfaceConfig{}, INTERFACE_default},
+		{"INTERFACE crc", InterfaceConfig{Timeout: true, Status: true, Check: CheckCRC}, &InterfaceConfig{}, 0x0E},
+		{"MODE0 default", Mode0{}, &Mode0{}, MODE0_default},
+		{"MODE0 pulse", Mode0{RefReverse: true, RunMode: RunPulse, Chop: ChopInput, Delay: Delay1_1ms}, &Mode0{}, 0xD8},
+		{"MODE0 longest delay", Mode0{Chop: ChopInputAndIDACRotation, Delay: Delay8_8ms}, &Mode0{}, 0x3B},
+		{"MODE1 default", Mode1{Filter: FilterFIR}, &Mode1{}, MODE1_default},
+		{"MODE1 sensor bias", Mode1{Filter: FilterSinc3, SensorBiasADC: SensorBiasADC2, SensorBiasPol: SensorBiasPullDown, SensorBiasMag: SensorBias10uA}, &Mode1{}, 0x5B},
+		{"MODE2 default", Mode2{Gain: Gain1, Rate: Rate20}, &Mode2{}, MODE2_default},
+		{"MODE2 bypass", Mode2{Bypass: true, Rate: Rate38400}, &Mode2{}, 0x8F},
+		{"MODE2 gain", Mode2{Gain: Gain32, Rate: Rate400}, &Mode2{}, 0x58},
+		{"INPMUX default", InpMux{Positive: AIN0, Negative: AIN1}, &InpMux{}, INPMUX_default},
+		{"INPMUX temperature", InpMux{Positive: TempSensor, Negative: TempSensor}, &InpMux{}, 0xBB},
+		{"IDACMUX default", IDACMux{IDAC1: Float, IDAC2: Float}, &IDACMux{}, IDACMUX_default},
+		{"IDACMUX pins", IDACMux{IDAC1: AIN3, IDAC2: AINCOM}, &IDACMux{}, 0xA3},
+		{"IDACMAG default", IDACMag{}, &IDACMag{}, IDACMAG_default},
+		{"IDACMAG currents", IDACMag{IDAC1: IDAC500uA, IDAC2: IDAC3000uA}, &IDACMag{}, 0xA4},
+		{"REFMUX default", RefMux{}, &RefMux{}, REFMUX_default},
+		{"REFMUX external", RefMux{Positive: RefPAIN2, Negative: RefNAIN3}, &RefMux{}, 0x12},
+		{"REFMUX supply", RefMux{Positive: RefPAVDD, Negative: RefNAVSS}, &RefMux{}, 0x24},
+		{"TDACP", TDAC{Output: true, Magnitude: TDAC0_5}, &TDAC{}, 0x99},
+		{"TDACN", TDAC{Negative: true, Magnitude: TDAC3}, &TDAC{Negative: true}, 0x07},
+		{"ADC2CFG default", ADC2Config{}, &ADC2Config{}, ADC2CFG_default},
+		{"ADC2CFG", ADC2Config{Rate: ADC2Rate800, Ref: ADC2RefSupply, Gain: ADC2Gain128}, &ADC2Config{}, 0xE7},
+		{"ADC2MUX default", ADC2Mux{Positive: AIN0, Negative: AIN1}, &ADC2Mux{}, ADC2MUX_default},
+		{"ADC2MUX supply", ADC2Mux{Positive: AnalogSupply, Negative: DigitalSupply}, &ADC2Mux{}, 0xCD},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.Encode()
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode() = 0x%02X, want 0x%02X", got, tt.want)
+			}
+			if tt.decoded.Address() != tt.config.Address() {
+				t.Fatalf("Address() = 0x%02X after decoding, want 0x%02X", tt.decoded.Address(), tt.config.Address())
+			}
+			if err := tt.decoded.Decode(tt.want); err != nil {
+				t.Fatalf("Decode(0x%02X) error: %v", tt.want, err)
+			}
+			if decoded := reflect.ValueOf(tt.decoded).Elem().Interface(); !reflect.DeepEqual(decoded, tt.config) {
+				t.Errorf("Decode(0x%02X) = %+v, want %+v", tt.want, decoded, tt.config)
+			}
+		})
+	}
+}
+
+func TestRegisterConfigEncodeRejectsOutOfRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RegisterConfig
+	}{
+		{"INTERFACE check", InterfaceConfig{Check: CheckCRC + 1}},
+		{"MODE0 runmode", Mode0{RunMode: RunPulse + 1}},
+		{"MODE0 chop", Mode0{Chop: ChopInputAndIDACRotation + 1}},
+		{"MODE0 delay", Mode0{Delay: Delay8_8ms + 1}},
+		{"MODE1 filter", Mode1{Filter: FilterFIR + 1}},
+		{"MODE1 sbADC", Mode1{SensorBiasADC: SensorBiasADC2 + 1}},
+		{"MODE1 sbpol", Mode1{SensorBiasPol: SensorBiasPullDown + 1}},
+		{"MODE1 sbmag", Mode1{SensorBiasMag: SensorBias10MOhm + 1}},
+		{"MODE2 gain", Mode2{Gain: Gain32 + 1}},
+		{"MODE2 data rate", Mode2{Rate: Rate38400 + 1}},
+		{"INPMUX muxP", InpMux{Positive: Float + 1}},
+		{"INPMUX muxN", InpMux{Negative: Float + 1}},
+		{"IDACMUX mux1", IDACMux{IDAC1: TempSensor, IDAC2: Float}},
+		{"IDACMUX mux2", IDACMux{IDAC1: Float, IDAC2: AnalogSupply}},
+		{"IDACMAG mag1", IDACMag{IDAC1: IDAC3000uA + 1}},
+		{"IDACMAG mag2", IDACMag{IDAC2: IDAC3000uA + 1}},
+		{"REFMUX rmuxP", RefMux{Positive: RefPAVDD + 1}},
+		{"REFMUX rmuxN", RefMux{Negative: RefNAVSS + 1}},
+		{"TDAC magnitude", TDAC{Magnitude: 0b01010}},
+		{"ADC2CFG DR2", ADC2Config{Rate: ADC2Rate800 + 1}},
+		{"ADC2CFG REF2", ADC2Config{Ref: ADC2RefSupply + 1}},
+		{"ADC2CFG GAIN2", ADC2Config{Gain: ADC2Gain128 + 1}},
+		{"ADC2MUX muxN2", ADC2Mux{Negative: Float + 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.config.Encode(); err == nil {
+				t.Errorf("Encode() = 0x%02X, want an error", got)
+			}
+		})
+	}
+}
+
+func TestRegisterConfigDecodeRejectsReserved(t *testing.T) {
+	tests := []struct {
+		name    string
+		decoded RegisterReader
+		value   byte
+	}{
+		{"INTERFACE check", &InterfaceConfig{}, 0x07},
+		{"MODE0 delay", &Mode0{}, 0x0C},
+		{"MODE1 filter", &Mode1{}, 0xA0},
+		{"MODE1 sbmag", &Mode1{}, 0x87},
+		{"MODE2 gain", &Mode2{}, 0x64},
+		{"IDACMUX mux1", &IDACMux{}, 0xBC},
+		{"IDACMUX mux2", &IDACMux{}, 0xFB},
+		{"IDACMAG mag1", &IDACMag{}, 0x0B},
+		{"IDACMAG mag2", &IDACMag{}, 0xF0},
+		{"REFMUX rmuxP", &RefMux{}, 0x28},
+		{"REFMUX rmuxN", &RefMux{}, 0x05},
+		{"TDAC magnitude", &TDAC{}, 0x0A},
+		{"ADC2CFG REF2", &ADC2Config{}, 0x28},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.decoded.Decode(tt.value); err == nil {
+				t.Errorf("Decode(0x%02X) = %+v, want an error", tt.value, tt.decoded)
+			}
+		})
+	}
+}
+
+func TestBuildRegister(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  byte
+		settings []byte
+		want     byte
+		wantErr  bool
+	}{
+		{"MODE2", MODE2_address, []byte{MODE2_bypass_PGAenabled, MODE2_GAIN_4, MODE2_DR_400}, 0x28, false},
+		{"same setting twice", MODE2_address, []byte{MODE2_DR_400, MODE2_DR_400}, MODE2_DR_400, false},
+		{"conflicting data rates", MODE2_address, []byte{MODE2_DR_20, MODE2_DR_400}, 0, true},
+		{"overlapping fields of REFMUX", REFMUX_address, []byte{REFMUX_rmuxP_AIN0, REFMUX_rmuxN_AIN1, REFMUX_rmuxP_AIN2}, 0, true},
+		//a zero value can't be told apart from an unset field
+		{"zero value never conflicts", MODE2_address, []byte{MODE2_DR_400, MODE2_DR_2_5}, MODE2_DR_400, false},
+		{"reserved bits", REFMUX_address, []byte{0b11000000}, 0, true},
+		{"register without settings", ID_address, []byte{0x01}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildRegister(tt.address, tt.settings...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildRegister() = 0x%02X, %v, want error %v", got, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("BuildRegister() = 0x%02X, want 0x%02X", got, tt.want)
+			}
+		})
+	}
+}
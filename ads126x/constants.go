@@ -28,6 +28,37 @@ const START1 byte = 0x08
 //Reset the ADC (opcode)
 const RESET byte = 0x06
 
+//ADC2 (ADS1263 only) opcodes
+//Start ADC2 conversions (opcode)
+const START2 byte = 0x0C
+
+//Stop ADC2 conversions (opcode)
+const STOP2 byte = 0x0E
+
+//Read ADC2 data (opcode)
+const RDATA2 byte = 0x14
+
+//ADC2 system offset calibration (opcode)
+const SYOCAL2 byte = 0x1B
+
+//ADC2 system gain calibration (opcode)
+const SYGCAL2 byte = 0x1C
+
+//ADC2 self offset calibration (opcode)
+const SFOCAL2 byte = 0x1E
+
+//Status byte - sent before the conversion data when INTERFACE_status_enabled is set. It holds the new data flags and the alarms (see section 9.4.7 of the datasheet)
+const (
+	STATUS_ADC2     byte = 0b10000000 //ADC2 has new data since the last ADC2 read (ADS1263 only)
+	STATUS_ADC1     byte = 0b01000000 //ADC1 has new data since the last ADC1 read
+	STATUS_EXTCLK   byte = 0b00100000 //the ADC clock source is external
+	STATUS_REF_ALM  byte = 0b00010000 //ADC1 low reference alarm
+	STATUS_PGAL_ALM byte = 0b00001000 //ADC1 PGA output low alarm
+	STATUS_PGAH_ALM byte = 0b00000100 //ADC1 PGA output high alarm
+	STATUS_PGAD_ALM byte = 0b00000010 //ADC1 PGA differential output range alarm
+	STATUS_RESET    byte = 0b00000001 //the ADC was reset since the RESET bit in the POWER register was last cleared
+)
+
 //First register is the Device identification register which is read only. Reading this register gives device version and revision.
 const (
 	ID_address byte = 0x00
@@ -38,6 +69,11 @@ const (
 	POWER_address byte = 0x01
 	POWER_default byte = 0x11
 
+	//bit masks of the fields in the register
+	POWER_reset_mask  byte = 0b00010000
+	POWER_vbias_mask  byte = 0b00000010
+	POWER_intref_mask byte = 0b00000001
+
 	//reset indicator (Indicates ADC reset has occurred. Clear this bit to detect the next device reset.)
 	POWER_reset_no  byte = 0b00000000
 	POWER_reset_yes byte = 0b00010000
@@ -56,6 +92,11 @@ const (
 	INTERFACE_address byte = 0x02
 	INTERFACE_default byte = 0x05
 
+	//bit masks of the fields in the register
+	INTERFACE_timeout_mask byte = 0b00001000
+	INTERFACE_status_mask  byte = 0b00000100
+	INTERFACE_crc_mask     byte = 0b00000011
+
 	//Serial Interface Time-Out Enable (Enables the serial interface automatic time-out mode)
 	INTERFACE_timeout_disabled byte = 0b00000000
 	INTERFACE_timeout_enabled  byte = 0b00001000
@@ -75,6 +116,12 @@ const (
 	MODE0_address byte = 0x03
 	MODE0_default byte = 0x00
 
+	//bit masks of the fields in the register
+	MODE0_refrev_mask  byte = 0b10000000
+	MODE0_runmode_mask byte = 0b01000000
+	MODE0_chop_mask    byte = 0b00110000
+	MODE0_delay_mask   byte = 0b00001111
+
 	//Reference Mux Polarity Reversal (Reverses the ADC1 reference multiplexer output polarity)
 	MODE0_refrev_normalpolarity  byte = 0b00000000
 	MODE0_refrev_reversepolarity byte = 0b10000000
@@ -109,6 +156,12 @@ const (
 	MODE1_address byte = 0x04
 	MODE1_default byte = 0x80
 
+	//bit masks of the fields in the register
+	MODE1_filter_mask byte = 0b11100000
+	MODE1_sbADC_mask  byte = 0b00010000
+	MODE1_sbpol_mask  byte = 0b00001000
+	MODE1_sbmag_mask  byte = 0b00000111
+
 	// Digital Filter (Configures the ADC digital filter)
 	MODE1_filter_sinc1 byte = 0b00000000
 	MODE1_filter_sinc2 byte = 0b00100000
@@ -139,6 +192,11 @@ const (
 	MODE2_address byte = 0x05
 	MODE2_default byte = 0x04
 
+	//bit masks of the fields in the register
+	MODE2_bypass_mask byte = 0b10000000
+	MODE2_GAIN_mask   byte = 0b01110000
+	MODE2_DR_mask     byte = 0b00001111
+
 	//PGA Bypass Mode Selects PGA bypass mode
 	MODE2_bypass_PGAenabled  byte = 0b00000000 //default
 	MODE2_bypass_PGAdisabled byte = 0b10000000
@@ -175,6 +233,10 @@ const (
 	INPMUX_address byte = 0x06
 	INPMUX_default byte = 0x01
 
+	//bit masks of the fields in the register
+	INPMUX_muxP_mask byte = 0b11110000
+	INPMUX_muxN_mask byte = 0b00001111
+
 	//Positive Input Multiplexer (Selects the positive input multiplexer.)
 	INPMUX_muxP_AIN0           byte = 0b00000000 //positive input is AIN0 (default)
 	INPMUX_muxP_AIN1           byte = 0b00010000 //positive input is AIN1
@@ -230,6 +292,10 @@ const (
 	IDACMUX_address byte = 0x0D
 	IDACMUX_default byte = 0xBB
 
+	//bit masks of the fields in the register
+	IDACMUX_mux2_mask byte = 0b11110000
+	IDACMUX_mux1_mask byte = 0b00001111
+
 	//IDAC2 Output Multiplexer Selects the analog input pin to connect IDAC2
 	IDACMUX_mux2_AIN0   byte = 0b00000000 //IDAC2 output is AIN0
 	IDACMUX_mux2_AIN1   byte = 0b00010000 //IDAC2 output is AIN1
@@ -264,6 +330,11 @@ const (
 const (
 	IDACMAG_address byte = 0x0E
 	IDACMAG_default byte = 0x00
+
+	//bit masks of the fields in the register
+	IDACMAG_mag2_mask byte = 0b11110000
+	IDACMAG_mag1_mask byte = 0b00001111
+
 	//IDAC2 Output Multiplexer Selects the analog input pin to connect IDAC2
 	IDACMAG_mag2_off  byte = 0b00000000 //IDAC2 is off (default)
 	IDACMAG_mag2_50   byte = 0b00010000 //IDAC2 output is 50 µA
@@ -274,7 +345,8 @@ const (
 	IDACMAG_mag2_1000 byte = 0b01100000
 	IDACMAG_mag2_1500 byte = 0b01110000
 	IDACMAG_mag2_2000 byte = 0b10000000
-	IDACMAG_mag2_3000 byte = 0b10010000
+	IDACMAG_mag2_2500 byte = 0b10010000
+	IDACMAG_mag2_3000 byte = 0b10100000
 
 	//IDAC1 Output Multiplexer Selects the analog input pin to connect IDAC1
 	IDACMAG_mag1_off  byte = 0b00000000 //IDAC1 is off
@@ -294,6 +366,11 @@ const (
 const (
 	REFMUX_address byte = 0x0F
 	REFMUX_default byte = 0x00
+
+	//bit masks of the fields in the register
+	REFMUX_rmuxP_mask byte = 0b00111000
+	REFMUX_rmuxN_mask byte = 0b00000111
+
 	//Reference Positive Input (Selects the positive reference input)
 	REFMUX_rmuxP_internalRef   byte = 0b00000000 //Internal 2.5 V reference - P (default)
 	REFMUX_rmuxP_AIN0          byte = 0b00001000 //External AIN0
@@ -305,13 +382,21 @@ const (
 	REFMUX_rmuxN_AIN1          byte = 0b00000001 //External AIN1
 	REFMUX_rmuxN_AIN3          byte = 0b00000010
 	REFMUX_rmuxN_AIN5          byte = 0b00000011
-	REFMUX_rmuxP_internalVavss byte = 0b00000100 //Internal analog supply (VAVSS)
+	REFMUX_rmuxN_internalVavss byte = 0b00000100 //Internal analog supply (VAVSS)
+
+	//REFMUX_rmuxP_internalVavss is the old, misspelled name of REFMUX_rmuxN_internalVavss. VAVSS can only be selected as the negative reference.
+	REFMUX_rmuxP_internalVavss byte = REFMUX_rmuxN_internalVavss
 )
 
 //TDACP control register - Test DAC (positive)
 const (
 	TDACP_address byte = 0x10
 	TDACP_default byte = 0x00
+
+	//bit masks of the fields in the register
+	TDACP_outP_mask byte = 0b10000000
+	TDACP_magP_mask byte = 0b00011111
+
 	// TDACP Output Connection (Connects TDACP output to pin AIN6)
 	TDACP_outP_none byte = 0b00000000
 	TDACP_outP_AIN6 byte = 0b10000000
@@ -339,9 +424,14 @@ const (
 
 //TDACN control register - Test DAC (negative)
 const (
-	TDACN_address byte = 0x10
+	TDACN_address byte = 0x11
 	TDACN_default byte = 0x00
-	// TDACN Output Connection (Connects TDACN output to pin AIN6)
+
+	//bit masks of the fields in the register
+	TDACN_outN_mask byte = 0b10000000
+	TDACN_magN_mask byte = 0b00011111
+
+	// TDACN Output Connection (Connects TDACN output to pin AIN7)
 	TDACN_outN_none byte = 0b00000000
 	TDACN_outN_AIN7 byte = 0b10000000
 	// MAGN Output Magnitude Select the TDACN output magnitude. (The TDAC output voltages are ideal and are with respect to VAVSS)
@@ -365,3 +455,127 @@ const (
 	TDACN_magN_1_5       byte = 0b00011000 // 1.5 V
 	TDACN_magN_0_5       byte = 0b00011001 // 0.5 V
 )
+
+//GPIOCON connection register - connects the GPIOs to the analog pins AIN3 to AIN9 and AINCOM. Each bit connects one pin, a connected pin is a GPIO and can't be used as an analog input at the same time.
+const (
+	GPIOCON_address byte = 0x12
+	GPIOCON_default byte = 0x00
+
+	GPIOCON_AIN3   byte = 0b00000001
+	GPIOCON_AIN4   byte = 0b00000010
+	GPIOCON_AIN5   byte = 0b00000100
+	GPIOCON_AIN6   byte = 0b00001000
+	GPIOCON_AIN7   byte = 0b00010000
+	GPIOCON_AIN8   byte = 0b00100000
+	GPIOCON_AIN9   byte = 0b01000000
+	GPIOCON_AINCOM byte = 0b10000000
+)
+
+//GPIODIR direction register - each bit sets the direction of the GPIO with the same bit in GPIOCON (0 = output, 1 = input)
+const (
+	GPIODIR_address byte = 0x13
+	GPIODIR_default byte = 0x00
+
+	GPIODIR_output byte = 0
+	GPIODIR_input  byte = 1
+)
+
+//GPIODAT data register - writing sets the level of the GPIOs that are outputs, reading gives the level of every GPIO. Bits follow GPIOCON.
+const (
+	GPIODAT_address byte = 0x14
+	GPIODAT_default byte = 0x00
+)
+
+//ADC2 configuration register (ADS1263 only) - sets the ADC2 data rate, reference and gain
+const (
+	ADC2CFG_address byte = 0x15
+	ADC2CFG_default byte = 0x00
+
+	//bit masks of the fields in the register
+	ADC2CFG_DR2_mask   byte = 0b11000000
+	ADC2CFG_REF2_mask  byte = 0b00111000
+	ADC2CFG_GAIN2_mask byte = 0b00000111
+
+	//ADC2 Data Rate (Selects the ADC2 data rate)
+	ADC2CFG_DR2_10  byte = 0b00000000 //10 samples per second (default)
+	ADC2CFG_DR2_100 byte = 0b01000000
+	ADC2CFG_DR2_400 byte = 0b10000000
+	ADC2CFG_DR2_800 byte = 0b11000000
+
+	//ADC2 Reference Input (Selects the ADC2 reference inputs)
+	ADC2CFG_REF2_internalRef        byte = 0b00000000 //Internal 2.5 V reference (default)
+	ADC2CFG_REF2_AIN0_AIN1          byte = 0b00001000 //External AIN0 and AIN1 pin pairs
+	ADC2CFG_REF2_AIN2_AIN3          byte = 0b00010000 //External AIN2 and AIN3 pin pairs
+	ADC2CFG_REF2_AIN4_AIN5          byte = 0b00011000 //External AIN4 and AIN5 pin pairs
+	ADC2CFG_REF2_internalVavddVavss byte = 0b00100000 //Internal analog supply (VAVDD and VAVSS)
+
+	//ADC2 Gain (Selects the ADC2 gain)
+	ADC2CFG_GAIN2_1   byte = 0b00000000 //1 V/V (default)
+	ADC2CFG_GAIN2_2   byte = 0b00000001 //2 V/V
+	ADC2CFG_GAIN2_4   byte = 0b00000010 //4 V/V
+	ADC2CFG_GAIN2_8   byte = 0b00000011 //8 V/V
+	ADC2CFG_GAIN2_16  byte = 0b00000100 //16 V/V
+	ADC2CFG_GAIN2_32  byte = 0b00000101 //32 V/V
+	ADC2CFG_GAIN2_64  byte = 0b00000110 //64 V/V
+	ADC2CFG_GAIN2_128 byte = 0b00000111 //128 V/V
+)
+
+//ADC2 input multiplexer register (ADS1263 only) - sets the ADC2 input channels. The input codes are the same as for INPMUX.
+const (
+	ADC2MUX_address byte = 0x16
+	ADC2MUX_default byte = 0x01
+
+	//bit masks of the fields in the register
+	ADC2MUX_muxP2_mask byte = 0b11110000
+	ADC2MUX_muxN2_mask byte = 0b00001111
+
+	//ADC2 Positive Input Multiplexer (Selects the ADC2 positive input)
+	ADC2MUX_muxP2_AIN0           byte = 0b00000000 //positive input is AIN0 (default)
+	ADC2MUX_muxP2_AIN1           byte = 0b00010000 //positive input is AIN1
+	ADC2MUX_muxP2_AIN2           byte = 0b00100000 //positive input is AIN2
+	ADC2MUX_muxP2_AIN3           byte = 0b00110000 //positive input is AIN3
+	ADC2MUX_muxP2_AIN4           byte = 0b01000000 //positive input is AIN4
+	ADC2MUX_muxP2_AIN5           byte = 0b01010000 //positive input is AIN5
+	ADC2MUX_muxP2_AIN6           byte = 0b01100000 //positive input is AIN6
+	ADC2MUX_muxP2_AIN7           byte = 0b01110000 //positive input is AIN7
+	ADC2MUX_muxP2_AIN8           byte = 0b10000000 //positive input is AIN8
+	ADC2MUX_muxP2_AIN9           byte = 0b10010000 //positive input is AIN9
+	ADC2MUX_muxP2_AINCOM         byte = 0b10100000 //positive input is AINCOM
+	ADC2MUX_muxP2_tempSensorP    byte = 0b10110000 //Temperature sensor monitor positive
+	ADC2MUX_muxP2_analogSupplyP  byte = 0b11000000 //Analog power supply monitor positive
+	ADC2MUX_muxP2_digitalSupplyP byte = 0b11010000 //Digital power supply monitor positive
+	ADC2MUX_muxP2_TDACP          byte = 0b11100000 //TDAC test signal positive
+	ADC2MUX_muxP2_float          byte = 0b11110000 //Float (open connection)
+
+	//ADC2 Negative Input Multiplexer (Selects the ADC2 negative input)
+	ADC2MUX_muxN2_AIN0           byte = 0b00000000 //negative input is AIN0
+	ADC2MUX_muxN2_AIN1           byte = 0b00000001 //negative input is AIN1 (default)
+	ADC2MUX_muxN2_AIN2           byte = 0b00000010 //negative input is AIN2
+	ADC2MUX_muxN2_AIN3           byte = 0b00000011 //negative input is AIN3
+	ADC2MUX_muxN2_AIN4           byte = 0b00000100 //negative input is AIN4
+	ADC2MUX_muxN2_AIN5           byte = 0b00000101 //negative input is AIN5
+	ADC2MUX_muxN2_AIN6           byte = 0b00000110 //negative input is AIN6
+	ADC2MUX_muxN2_AIN7           byte = 0b00000111 //negative input is AIN7
+	ADC2MUX_muxN2_AIN8           byte = 0b00001000 //negative input is AIN8
+	ADC2MUX_muxN2_AIN9           byte = 0b00001001 //negative input is AIN9
+	ADC2MUX_muxN2_AINCOM         byte = 0b00001010 //negative input is AINCOM
+	ADC2MUX_muxN2_tempSensorN    byte = 0b00001011 //Temperature sensor monitor negative
+	ADC2MUX_muxN2_analogSupplyN  byte = 0b00001100 //Analog power supply monitor negative
+	ADC2MUX_muxN2_digitalSupplyN byte = 0b00001101 //Digital power supply monitor negative
+	ADC2MUX_muxN2_TDACN          byte = 0b00001110 //TDAC test signal negative
+	ADC2MUX_muxN2_float          byte = 0b00001111 //Float (open connection)
+)
+
+//ADC2 offset calibration register (ADS1263 only) - a 16 bit two's complement value stored low byte first. It is subtracted from the ADC2 conversion result. (see section 9.4.9 of the datasheet for more information)
+const (
+	ADC2OFC0_address byte = 0x17
+	ADC2OFC1_address byte = 0x18
+)
+
+//ADC2 full-scale calibration register (ADS1263 only) - a 16 bit value stored low byte first. The ADC2 conversion result is multiplied by this value divided by 4000h. (see section 9.4.9 of the datasheet for more information)
+const (
+	ADC2FSC0_address byte = 0x19
+	ADC2FSC1_address byte = 0x1A
+	ADC2FSC0_default byte = 0x00
+	ADC2FSC1_default byte = 0x40
+)
@@ -0,0 +1,358 @@
+package ads126x
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"periph.io/x/periph/conn/gpio"
+	"periph.io/x/periph/conn/spi"
+)
+
+//Pins holds the optional GPIO pins wired to the ADS126x. Any of them can be left nil. Without a START pin conversions are started and stopped with the START1/STOP1 opcodes, without a PWDN pin the chip is reset with the RESET opcode and without a DRDY pin reads have to be done by command.
+type Pins struct {
+	Start gpio.PinIO
+	Pwdn  gpio.PinIO
+	Drdy  gpio.PinIO
+}
+
+//Device is a single ADS1262 or ADS1263 connected over SPI. It owns the SPI connection, the control pins and the buffers used for reading conversion data so several devices can be used in one program without interfering with each other. Use New to create one.
+type Device struct {
+	conn spi.Conn
+	pins Pins
+
+	//mu guards the SPI transfers and the buffers below
+	mu sync.Mutex
+
+	//regs holds the last values written to or read from each register (the shadow). The INTERFACE register decides the layout of the conversion data frame and the MODE registers decide how long to wait for data.
+	regs [numRegisters]byte
+	//known is true for the registers whose value in regs is known to match the chip, because it was read, written or reset since the device was created
+	known [numRegisters]bool
+
+	//buffers used for reading conversion data directly (continuous read mode). They are sized for the longest frame and sliced to the frame length in use.
+	conversionbytes []byte
+	empty           []byte
+
+	//buffers used for reading conversion data with the RDATA1 command
+	readcommand            []byte
+	commandconversionbytes []byte
+
+	//buffers used for reading ADC2 conversion data with the RDATA2 command (ADS1263 only)
+	readcommand2 []byte
+	adc2bytes    []byte
+
+	//pollinterval is how long to wait between RDATA reads when polling the status byte for new data
+	pollinterval time.Duration
+
+	//timeout is how long to wait for new data. Zero means it is worked out from the MODE registers and a negative value means wait forever.
+	timeout time.Duration
+
+	//extref is the external reference voltage in volts, used to convert data when REFMUX or ADC2CFG selects an external reference
+	extref float64
+
+	//clippolicy decides what the read functions do with clipped samples
+	clippolicy ClipPolicy
+
+	//validation decides whether register writes are validated first
+	validation Validation
+
+	//writeretries is how many times WriteVerified writes again after a failed readback
+	writeretries int
+
+	//tempcal corrects the temperature sensor readings
+	tempcal TemperatureCalibration
+}
+
+//maxFrame is the longest conversion data frame - status byte, four data bytes and the check byte
+const maxFrame = 6
+
+//These are the delays used when resetting the chip. They are based on fig 159 of the datasheet with extra margin for the internal reference to settle.
+var (
+	resetPulse  = 500 * time.Millisecond
+	resetSettle = 2 * time.Second
+)
+
+//New creates a Device from an SPI connection and the optional control pins. The connection must use SPI mode 1 with 8 bits per word. The device assumes the INTERFACE register holds its default value (status byte enabled, checksum in checksum mode), which is also how the examples configure it. Writing the INTERFACE register through WriteRegisters updates this automatically, and the read functions then use the matching frame layout.
+func New(connection spi.Conn, pins Pins) *Device {
+	return &Device{
+		conn:                   connection,
+		pins:                   pins,
+		regs:                   registerDefaults,
+		conversionbytes:        make([]byte, maxFrame),
+		empty:                  make([]byte, maxFrame),
+		readcommand:            append([]byte{RDATA1}, make([]byte, maxFrame)...),
+		commandconversionbytes: make([]byte, maxFrame+1),
+		readcommand2:           append([]byte{RDATA2}, make([]byte, maxFrame)...),
+		adc2bytes:              make([]byte, maxFrame+1),
+		pollinterval:           time.Millisecond,
+		extref:                 InternalReference,
+		writeretries:           defaultWriteRetries,
+	}
+}
+
+//Pins returns the control pins the device was created with
+func (d *Device) Pins() Pins {
+	return d.pins
+}
+
+//command sends a single byte opcode
+func (d *Device) command(opcode byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.conn.Tx([]byte{opcode}, make([]byte, 1)); err != nil {
+		return &SPIError{Op: opcodeName(opcode), Err: err}
+	}
+	return nil
+}
+
+//setPin sets one of the control pins and wraps any failure in a PinError
+func setPin(name string, pin gpio.PinIO, level gpio.Level) error {
+	if err := pin.Out(level); err != nil {
+		return &PinError{Pin: name, Err: err}
+	}
+	return nil
+}
+
+//Reset resets the ADS126x and leaves conversions stopped. If a PWDN pin was given it is pulsed low (fig 159 of the datasheet), otherwise the RESET opcode is sent. All registers return to their default values.
+func (d *Device) Reset() error {
+	if d.pins.Pwdn != nil {
+		if err := setPin("PWDN", d.pins.Pwdn, gpio.Low); err != nil {
+			return err
+		}
+		time.Sleep(resetPulse)
+		if err := setPin("PWDN", d.pins.Pwdn, gpio.High); err != nil {
+			return err
+		}
+	} else if err := d.command(RESET); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.regs = registerDefaults
+	d.known = resetKnown
+	d.mu.Unlock()
+	if err := d.Stop(); err != nil {
+		return err
+	}
+	time.Sleep(resetSettle)
+	return nil
+}
+
+//numRegisters is the number of registers, from ID (00h) to ADC2FSC1 (1Ah)
+const numRegisters = 0x1B
+
+//registerDefaults are the register values after a reset. The ID register is read only and differs between chips.
+var registerDefaults = [numRegisters]byte{
+	POWER_address:     POWER_default,
+	INTERFACE_address: INTERFACE_default,
+	MODE0_address:     MODE0_default,
+	MODE1_address:     MODE1_default,
+	MODE2_address:     MODE2_default,
+	INPMUX_address:    INPMUX_default,
+	FSCAL2_address:    0x40,
+	IDACMUX_address:   IDACMUX_default,
+	IDACMAG_address:   IDACMAG_default,
+	REFMUX_address:    REFMUX_default,
+	TDACP_address:     TDACP_default,
+	TDACN_address:     TDACN_default,
+	ADC2CFG_address:   ADC2CFG_default,
+	ADC2MUX_address:   ADC2MUX_default,
+	ADC2FSC0_address:  ADC2FSC0_default,
+	ADC2FSC1_address:  ADC2FSC1_default,
+}
+
+//remember updates the copy of the registers kept by the device. d.mu must be held.
+func (d *Device) remember(startingreg byte, data []byte) {
+	for i, value := range data {
+		if reg := int(startingreg) + i; reg < numRegisters {
+			d.regs[reg] = value
+			d.known[reg] = true
+		}
+	}
+}
+
+//Start starts ADC1 conversions by bringing the START pin high or, if there is no START pin, by sending the START1 opcode
+func (d *Device) Start() error {
+	if d.pins.Start != nil {
+		return setPin("START", d.pins.Start, gpio.High)
+	}
+	return d.command(START1)
+}
+
+//Stop stops ADC1 conversions by bringing the START pin low or, if there is no START pin, by sending the STOP1 opcode
+func (d *Device) Stop() error {
+	if d.pins.Start != nil {
+		return setPin("START", d.pins.Start, gpio.Low)
+	}
+	return d.command(STOP1)
+}
+
+//WriteRegisters writes data to consecutive registers using the WREG opcode, starting at the register given. See section 9.5.7 of the datasheet. The settings are validated first and a ConfigError is returned, without writing anything, if they would be invalid (see SetValidation).
+func (d *Device) WriteRegisters(startingreg byte, datatowrite []byte) error {
+	if len(datatowrite) == 0 {
+		return nil
+	}
+	values := make(map[byte]byte, len(datatowrite))
+	for i, value := range datatowrite {
+		values[startingreg+byte(i)] = value
+	}
+	if err := d.checkWrite(values); err != nil {
+		return err
+	}
+	return d.writeRegisters(startingreg, datatowrite)
+}
+
+//writeRegisters is WriteRegisters without the validation
+func (d *Device) writeRegisters(startingreg byte, datatowrite []byte) error {
+	towrite := append([]byte{WREG | startingreg, byte(len(datatowrite) - 1)}, datatowrite...)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.conn.Tx(towrite, make([]byte, len(towrite))); err != nil {
+		return &SPIError{Op: "WREG", Err: err}
+	}
+	d.remember(startingreg, datatowrite)
+	return nil
+}
+
+//ReadRegisters reads numbertoread consecutive registers using the RREG opcode, starting at the register given. The shadow copy is updated with the values read, except that GPIODAT is marked as not matching the chip since it reads the pin levels rather than the output latch.
+func (d *Device) ReadRegisters(startingreg byte, numbertoread int) ([]byte, error) {
+	if numbertoread < 1 {
+		return nil, nil
+	}
+	towrite := make([]byte, 2+numbertoread)
+	towrite[0] = RREG | startingreg
+	towrite[1] = byte(numbertoread - 1)
+	toread := make([]byte, len(towrite))
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.conn.Tx(towrite, toread); err != nil {
+		return nil, &SPIError{Op: "RREG", Err: err}
+	}
+	d.remember(startingreg, toread[2:])
+	if startingreg <= GPIODAT_address && int(startingreg)+numbertoread > int(GPIODAT_address) {
+		//GPIODAT reads the level of the pins rather than the output latch, so the shadow can't be trusted to match what was written
+		d.known[GPIODAT_address] = false
+	}
+	return toread[2:], nil
+}
+
+//CheckRegisters reads back the registers starting at startingreg and compares them with expected. Read only and self clearing bits, like the ID register and the reset flag in POWER, are left out of the comparison. It returns a RegisterMismatchError listing the registers that differ.
+func (d *Device) CheckRegisters(startingreg byte, expected []byte) error {
+	incoming, err := d.ReadRegisters(startingreg, len(expected))
+	if err != nil {
+		return err
+	}
+	if diffs := diffRegisters(startingreg, expected, incoming); diffs != nil {
+		return &RegisterMismatchError{Start: startingreg, Wrote: append([]byte(nil), expected...), Read: incoming, Diffs: diffs}
+	}
+	return nil
+}
+
+//Read waits for the DRDY pin to go low and then reads the conversion data directly (continuous read mode). If DRDY doesn't go low within the time set with SetTimeout it returns a TimeoutError. The frame layout follows the INTERFACE register, so the status and check bytes may be enabled or not. If there is a check byte it is verified. The output is the unconverted 32 bit value. Use ReadSample to get the status byte as well.
+func (d *Device) Read() (int32, error) {
+	sample, err := d.ReadSample()
+	return sample.Raw, err
+}
+
+//ReadSample is like Read but returns the status byte with the conversion data
+func (d *Device) ReadSample() (Sample, error) {
+	if d.pins.Drdy == nil {
+		return Sample{}, ErrNoDRDYPin
+	}
+	return d.ReadContext(context.Background())
+}
+
+//readDirect reads one conversion data frame without an opcode. It must only be called right after DRDY went low.
+func (d *Device) readDirect() (Sample, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	layout, err := frameLayoutFor(d.regs[INTERFACE_address])
+	if err != nil {
+		return Sample{}, err
+	}
+	n := layout.length()
+	if err := d.conn.Tx(d.empty[:n], d.conversionbytes[:n]); err != nil {
+		return Sample{}, &SPIError{Op: "read", Err: err}
+	}
+	return layout.decodeADC1(d.conversionbytes[:n])
+}
+
+//ReadByCommand reads the latest conversion data with the RDATA1 opcode. Like Read, the frame layout follows the INTERFACE register. The output is the unconverted 32 bit value. Use ReadSampleByCommand to get the status byte as well.
+func (d *Device) ReadByCommand() (int32, error) {
+	sample, err := d.ReadSampleByCommand()
+	return sample.Raw, err
+}
+
+//ReadSampleByCommand is like ReadByCommand but returns the status byte with the conversion data
+func (d *Device) ReadSampleByCommand() (Sample, error) {
+	return d.applyClipPolicy(d.readSampleByCommand())
+}
+
+//readSampleByCommand reads the latest conversion data with RDATA1 without applying the clip policy
+func (d *Device) readSampleByCommand() (Sample, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	layout, err := frameLayoutFor(d.regs[INTERFACE_address])
+	if err != nil {
+		return Sample{}, err
+	}
+	n := layout.length() + 1
+	if err := d.conn.Tx(d.readcommand[:n], d.commandconversionbytes[:n]); err != nil {
+		return Sample{}, &SPIError{Op: "RDATA1", Err: err}
+	}
+	return layout.decodeADC1(d.commandconversionbytes[1:n])
+}
+
+//ReadNextByCommand reads the next new ADC1 conversion with the RDATA1 opcode. If the device has a DRDY pin it waits for it to go low first, otherwise it polls RDATA1 until the ADC1 new data bit in the status byte is set, which works on boards where DRDY isn't wired or is unreliable. A negative timeout waits forever. If the status byte shows that the data was already read before, the sample is returned together with ErrDuplicate.
+func (d *Device) ReadNextByCommand(timeout time.Duration) (Sample, error) {
+	return d.applyClipPolicy(d.readNextByCommand(context.Background(), timeout))
+}
+
+//SetPollInterval sets how long ReadNextByCommand waits between reads when it polls the status byte for new data. The default is 1 ms. A shorter interval lowers latency at high data rates but keeps the SPI bus busier.
+func (d *Device) SetPollInterval(interval time.Duration) {
+	d.mu.Lock()
+	d.pollinterval = interval
+	d.mu.Unlock()
+}
+
+//ReadInterface reads the INTERFACE register from the chip and uses it for the layout of the conversion data frames from then on. Use it if the register may have been changed without going through this Device. Like every register read it also updates the copy of the register the Device keeps.
+func (d *Device) ReadInterface() (InterfaceConfig, error) {
+	var iface InterfaceConfig
+	if err := d.ReadConfig(&iface); err != nil {
+		return iface, err
+	}
+	return iface, nil
+}
+
+//opcodeName gives the name of a stand-alone opcode for error messages
+func opcodeName(opcode byte) string {
+	switch opcode {
+	case START1:
+		return "START1"
+	case STOP1:
+		return "STOP1"
+	case RESET:
+		return "RESET"
+	case RDATA1:
+		return "RDATA1"
+	case START2:
+		return "START2"
+	case STOP2:
+		return "STOP2"
+	case RDATA2:
+		return "RDATA2"
+	case SFOCAL2:
+		return "SFOCAL2"
+	case SYOCAL2:
+		return "SYOCAL2"
+	case SYGCAL2:
+		return "SYGCAL2"
+	case SFOCAL1:
+		return "SFOCAL1"
+	case SYOCAL1:
+		return "SYOCAL1"
+	case SYGCAL1:
+		return "SYGCAL1"
+	}
+	return fmt.Sprintf("opcode 0x%02X", opcode)
+}
@@ -0,0 +1,134 @@
+package ads126x
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//Sentinel errors returned by this package. The typed errors below wrap them so they can be checked with errors.Is, or inspected in more detail with errors.As.
+var (
+	//ErrSPI means an SPI transfer to or from the ADC failed
+	ErrSPI = errors.New("ads126x: SPI transfer failed")
+
+	//ErrGPIO means setting one of the START or PWDN pins failed
+	ErrGPIO = errors.New("ads126x: GPIO pin failed")
+
+	//ErrChecksum means the conversion data did not match its checksum or CRC byte - a data transmission error occurred
+	ErrChecksum = errors.New("ads126x: checksum failed - data transmission error occurred")
+
+	//ErrDRDYTimeout means the DRDY pin (or the status byte when polling) did not signal new data in time. The ADC may have stopped converting or lost power - re-initialising it is usually the way out.
+	ErrDRDYTimeout = errors.New("ads126x: timed out waiting for data ready")
+
+	//ErrDuplicate means the conversion data read by command was already read before - no new conversion has finished since
+	ErrDuplicate = errors.New("ads126x: no new conversion data since the last read")
+
+	//ErrNoDRDYPin means a function that waits on the DRDY pin was used on a device without one
+	ErrNoDRDYPin = errors.New("ads126x: no data ready pin - use ReadByCommand instead")
+
+	//ErrFrameLayout means the conversion data frame set by the INTERFACE register can't be used by the read function called
+	ErrFrameLayout = errors.New("ads126x: conversion data frame does not match the INTERFACE register")
+
+	//ErrRegisterMismatch means the data read back from the registers is not what was written
+	ErrRegisterMismatch = errors.New("ads126x: register readback does not match")
+
+	//ErrClipped means the conversion data was clipped at full scale or the PGA was overloaded (see SetClipPolicy)
+	ErrClipped = errors.New("ads126x: conversion data clipped")
+
+	//ErrInvalidConfig means a register write was refused because the settings would be invalid
+	ErrInvalidConfig = errors.New("ads126x: invalid configuration")
+
+	//ErrNoProfile means no saved calibration profile matches the board and channel
+	ErrNoProfile = errors.New("ads126x: no matching calibration profile")
+
+	//ErrStaleProfile is a warning that the calibration profile applied is older than allowed
+	ErrStaleProfile = errors.New("ads126x: calibration profile is stale")
+
+	//ErrProfileTemperature is a warning that the calibration profile applied was taken at a very different temperature
+	ErrProfileTemperature = errors.New("ads126x: calibration profile was taken at a different temperature")
+)
+
+//SPIError is returned when an SPI transfer fails. Op describes what was being done at the time.
+type SPIError struct {
+	Op  string
+	Err error
+}
+
+func (e *SPIError) Error() string {
+	return fmt.Sprintf("ads126x: SPI transfer failed during %s: %v", e.Op, e.Err)
+}
+
+//Unwrap returns the error from the SPI connection
+func (e *SPIError) Unwrap() error { return e.Err }
+
+//Is makes errors.Is(err, ErrSPI) true for every SPIError
+func (e *SPIError) Is(target error) bool { return target == ErrSPI }
+
+//PinError is returned when setting a GPIO pin fails
+type PinError struct {
+	Pin string
+	Err error
+}
+
+func (e *PinError) Error() string {
+	return fmt.Sprintf("ads126x: setting %s pin failed: %v", e.Pin, e.Err)
+}
+
+//Unwrap returns the error from the GPIO pin
+func (e *PinError) Unwrap() error { return e.Err }
+
+//Is makes errors.Is(err, ErrGPIO) true for every PinError
+func (e *PinError) Is(target error) bool { return target == ErrGPIO }
+
+//ChecksumError is returned when the check byte received with the conversion data is not the expected one. Mode tells if it was a checksum or a CRC.
+type ChecksumError struct {
+	Mode CheckMode
+	Got  byte
+	Want byte
+}
+
+func (e *ChecksumError) Error() string {
+	kind := "checksum"
+	if e.Mode == CheckCRC {
+		kind = "CRC"
+	}
+	return fmt.Sprintf("ads126x: %s failed - data transmission error occurred (got 0x%02X, want 0x%02X)", kind, e.Got, e.Want)
+}
+
+//Is makes errors.Is(err, ErrChecksum) true for every ChecksumError
+func (e *ChecksumError) Is(target error) bool { return target == ErrChecksum }
+
+//RegisterMismatchError is returned when registers read back differ from what was written. Start is the address of the first register, Wrote and Read hold the data for consecutive registers from there. Diffs lists only the registers that differ.
+type RegisterMismatchError struct {
+	Start byte
+	Wrote []byte
+	Read  []byte
+	Diffs []RegisterDiff
+}
+
+func (e *RegisterMismatchError) Error() string {
+	if len(e.Diffs) == 0 {
+		return fmt.Sprintf("ads126x: register readback does not match starting at 0x%02X: wrote %X, read %X", e.Start, e.Wrote, e.Read)
+	}
+	diffs := make([]string, len(e.Diffs))
+	for i, diff := range e.Diffs {
+		diffs[i] = diff.String()
+	}
+	return "ads126x: register readback does not match: " + strings.Join(diffs, ", ")
+}
+
+//Is makes errors.Is(err, ErrRegisterMismatch) true for every RegisterMismatchError
+func (e *RegisterMismatchError) Is(target error) bool { return target == ErrRegisterMismatch }
+
+//TimeoutError is returned when no new conversion data arrived in time. Waited is how long the read waited.
+type TimeoutError struct {
+	Waited time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("ads126x: timed out waiting for data ready after %v", e.Waited)
+}
+
+//Is makes errors.Is(err, ErrDRDYTimeout) true for every TimeoutError
+func (e *TimeoutError) Is(target error) bool { return target == ErrDRDYTimeout }
@@ -0,0 +1,64 @@
+package ads126x
+
+import (
+	"periph.io/x/periph/conn"
+	"periph.io/x/periph/conn/spi"
+)
+
+//fakeConn is an spi.Conn that behaves like the ADS126x registers. GPIODAT is modelled like the chip - writes go to the output latch and reads give the level of the pins, which is the latch for outputs and inputs for inputs.
+type fakeConn struct {
+	regs [numRegisters]byte
+	//inputs are the levels driven onto the GPIO inputs from outside
+	inputs byte
+	//frame is sent after RDATA1 or RDATA2
+	frame []byte
+	//opcodes are the first bytes of every transfer
+	opcodes []byte
+}
+
+//newFakeConn returns a fakeConn with the registers at their defaults
+func newFakeConn() *fakeConn {
+	return &fakeConn{regs: registerDefaults}
+}
+
+func (f *fakeConn) String() string      { return "fake" }
+func (f *fakeConn) Duplex() conn.Duplex { return conn.Full }
+
+func (f *fakeConn) TxPackets(p []spi.Packet) error {
+	for _, packet := range p {
+		if err := f.Tx(packet.W, packet.R); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//pins are the levels GPIODAT reads back
+func (f *fakeConn) pins() byte {
+	outputs := f.regs[GPIOCON_address] &^ f.regs[GPIODIR_address]
+	return f.regs[GPIODAT_address]&outputs | f.inputs&^outputs
+}
+
+func (f *fakeConn) Tx(w, r []byte) error {
+	if len(w) == 0 {
+		return nil
+	}
+	f.opcodes = append(f.opcodes, w[0])
+	switch {
+	case w[0]&0xE0 == WREG && len(w) > 2:
+		copy(f.regs[w[0]&0x1F:], w[2:])
+	case w[0]&0xE0 == RREG && len(w) > 2:
+		start := int(w[0] & 0x1F)
+		for i := range r[2:] {
+			switch reg := start + i; {
+			case reg == int(GPIODAT_address):
+				r[2+i] = f.pins()
+			case reg < numRegisters:
+				r[2+i] = f.regs[reg]
+			}
+		}
+	case (w[0] == RDATA1 || w[0] == RDATA2) && len(r) > 1:
+		copy(r[1:], f.frame)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package ads126x
+
+import "fmt"
+
+//A conversion data frame is the optional status byte, four data bytes and the optional checksum or CRC byte, so it is 4, 5 or 6 bytes long depending on the INTERFACE register. When reading with RDATA1 or RDATA2 the opcode takes up the first byte as well. ADC2 sends three data bytes and a pad byte (00h) so its frames are the same length. Leaving out the status and check bytes makes reads shorter, which matters at the highest data rates.
+
+//frameLayout describes the conversion data frame for an INTERFACE register setting
+type frameLayout struct {
+	status bool
+	check  CheckMode
+}
+
+//frameLayoutFor works out the frame layout from an INTERFACE register data byte
+func frameLayoutFor(iface byte) (frameLayout, error) {
+	var c InterfaceConfig
+	if err := c.Decode(iface); err != nil {
+		return frameLayout{}, fmt.Errorf("%w: INTERFACE is 0x%02X which sets a reserved check mode", ErrFrameLayout, iface)
+	}
+	return frameLayout{status: c.Status, check: c.Check}, nil
+}
+
+//length is the number of bytes in the frame, not counting the opcode byte when reading by command
+func (l frameLayout) length() int {
+	n := 4
+	if l.status {
+		n++
+	}
+	if l.check != CheckDisabled {
+		n++
+	}
+	return n
+}
+
+//dataOffset is the position of the first data byte in the frame
+func (l frameLayout) dataOffset() int {
+	if l.status {
+		return 1
+	}
+	return 0
+}
+
+//split verifies the check byte of a frame and returns the status byte and the four data bytes
+func (l frameLayout) split(frame []byte) (Status, []byte, error) {
+	i := l.dataOffset()
+	data := frame[i : i+4]
+	if l.check != CheckDisabled {
+		if err := verifyCheckByte(l.check, data, frame[i+4]); err != nil {
+			return 0, nil, err
+		}
+	}
+	var status Status
+	if l.status {
+		status = Status(frame[0])
+	}
+	return status, data, nil
+}
+
+//decodeADC1 turns an ADC1 frame into a sample with the 32 bit conversion value
+func (l frameLayout) decodeADC1(frame []byte) (Sample, error) {
+	status, data, err := l.split(frame)
+	if err != nil {
+		return Sample{}, err
+	}
+	rawdata := uint32(data[0])<<24 | uint32(data[1])<<16 | uint32(data[2])<<8 | uint32(data[3])
+	raw := int32(rawdata)
+	return Sample{Raw: raw, Status: status, HasStatus: l.status, Clipping: adc1Clipping(raw, status)}, nil
+}
+
+//decodeADC2 turns an ADC2 frame into a sample with the 24 bit conversion value sign extended
+func (l frameLayout) decodeADC2(frame []byte) (Sample, error) {
+	status, data, err := l.split(frame)
+	if err != nil {
+		return Sample{}, err
+	}
+	raw := signExtend24(data[0], data[1], data[2])
+	return Sample{Raw: raw, Status: status, HasStatus: l.status, Clipping: clippingOf(raw, adc2PositiveClip, adc2NegativeClip)}, nil
+}
+
+//FrameLength is the number of bytes read for each conversion in continuous read mode (4, 5 or 6) with the current INTERFACE setting. Reading by command takes one more byte for the opcode.
+func (d *Device) FrameLength() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	layout, _ := frameLayoutFor(d.regs[INTERFACE_address])
+	return layout.length()
+}
+
+//needStatus returns an error if the status byte is not enabled. Functions that poll the new data flags need it.
+func (d *Device) needStatus() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.regs[INTERFACE_address]&INTERFACE_status_mask == 0 {
+		return fmt.Errorf("%w: the status byte must be enabled (INTERFACE is 0x%02X)", ErrFrameLayout, d.regs[INTERFACE_address])
+	}
+	return nil
+}
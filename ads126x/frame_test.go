@@ -0,0 +1,128 @@
+package ads126x
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFrameLayoutFor(t *testing.T) {
+	tests := []struct {
+		iface  byte
+		want   frameLayout
+		length int
+	}{
+		{0x00, frameLayout{}, 4},
+		{0x01, frameLayout{check: CheckChecksum}, 5},
+		{0x02, frameLayout{check: CheckCRC}, 5},
+		{0x04, frameLayout{status: true}, 5},
+		{INTERFACE_default, frameLayout{status: true, check: CheckChecksum}, 6},
+		{0x06, frameLayout{status: true, check: CheckCRC}, 6},
+		//the time-out bit doesn't change the frame
+		{0x0E, frameLayout{status: true, check: CheckCRC}, 6},
+	}
+	for _, tt := range tests {
+		got, err := frameLayoutFor(tt.iface)
+		if err != nil {
+			t.Errorf("frameLayoutFor(0x%02X) error: %v", tt.iface, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("frameLayoutFor(0x%02X) = %+v, want %+v", tt.iface, got, tt.want)
+		}
+		if got.length() != tt.length {
+			t.Errorf("frameLayoutFor(0x%02X).length() = %d, want %d", tt.iface, got.length(), tt.length)
+		}
+	}
+	for _, iface := range []byte{0x03, 0x07} {
+		if _, err := frameLayoutFor(iface); !errors.Is(err, ErrFrameLayout) {
+			t.Errorf("frameLayoutFor(0x%02X) error = %v, want ErrFrameLayout", iface, err)
+		}
+	}
+}
+
+func TestDecodeADC1(t *testing.T) {
+	tests := []struct {
+		name  string
+		iface byte
+		frame []byte
+		want  Sample
+	}{
+		{"data only", 0x00, []byte{0x00, 0x00, 0x01, 0x00}, Sample{Raw: 256}},
+		{"status", 0x04, []byte{0x40, 0xFF, 0xFF, 0xFF, 0xFE}, Sample{Raw: -2, Status: 0x40, HasStatus: true}},
+		{"checksum", INTERFACE_default, []byte{0x40, 0x12, 0x34, 0x56, 0x78, 0xAF}, Sample{Raw: 0x12345678, Status: 0x40, HasStatus: true}},
+		{"crc", 0x06, []byte{0x40, 0x12, 0x34, 0x56, 0x78, 0x1C}, Sample{Raw: 0x12345678, Status: 0x40, HasStatus: true}},
+		{"crc without status", 0x02, []byte{0x7F, 0xFF, 0xFF, 0xFF, 0xEF}, Sample{Raw: 0x7FFFFFFF, Clipping: ClippedPositive}},
+		{"negative full scale", 0x01, []byte{0x80, 0x00, 0x00, 0x00, 0x1B}, Sample{Raw: -0x80000000, Clipping: ClippedNegative}},
+		{"PGA alarm", 0x04, []byte{0x40 | STATUS_PGAH_ALM, 0x12, 0x34, 0x56, 0x78}, Sample{Raw: 0x12345678, Status: Status(0x40 | STATUS_PGAH_ALM), HasStatus: true, Clipping: ClippedPositive}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := frameLayoutFor(tt.iface)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tt.frame) != layout.length() {
+				t.Fatalf("frame is %d bytes, the layout is %d", len(tt.frame), layout.length())
+			}
+			got, err := layout.decodeADC1(tt.frame)
+			if err != nil {
+				t.Fatalf("decodeADC1(% X) error: %v", tt.frame, err)
+			}
+			if got != tt.want {
+				t.Errorf("decodeADC1(% X) = %+v, want %+v", tt.frame, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeADC2(t *testing.T) {
+	tests := []struct {
+		name  string
+		iface byte
+		frame []byte
+		want  Sample
+	}{
+		{"data only", 0x00, []byte{0x00, 0x01, 0x00, 0x00}, Sample{Raw: 256}},
+		{"sign extended", INTERFACE_default, []byte{0x80, 0xFF, 0xFF, 0xFE, 0x00, 0x97}, Sample{Raw: -2, Status: 0x80, HasStatus: true}},
+		{"positive full scale", 0x00, []byte{0x7F, 0xFF, 0xFF, 0x00}, Sample{Raw: 0x7FFFFF, Clipping: ClippedPositive}},
+		{"negative full scale", 0x02, []byte{0x80, 0x00, 0x00, 0x00, 0x31}, Sample{Raw: -0x800000, Clipping: ClippedNegative}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := frameLayoutFor(tt.iface)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := layout.decodeADC2(tt.frame)
+			if err != nil {
+				t.Fatalf("decodeADC2(% X) error: %v", tt.frame, err)
+			}
+			if got != tt.want {
+				t.Errorf("decodeADC2(% X) = %+v, want %+v", tt.frame, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsBadCheckByte(t *testing.T) {
+	tests := []struct {
+		iface byte
+		frame []byte
+	}{
+		{INTERFACE_default, []byte{0x40, 0x12, 0x34, 0x56, 0x78, 0x1C}},
+		{0x06, []byte{0x40, 0x12, 0x34, 0x56, 0x78, 0xAF}},
+		{0x02, []byte{0x12, 0x34, 0x56, 0x79, 0x1C}},
+	}
+	for _, tt := range tests {
+		layout, err := frameLayoutFor(tt.iface)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := layout.decodeADC1(tt.frame); !errors.Is(err, ErrChecksum) {
+			t.Errorf("decodeADC1(% X) error = %v, want ErrChecksum", tt.frame, err)
+		}
+		if _, err := layout.decodeADC2(tt.frame); !errors.Is(err, ErrChecksum) {
+			t.Errorf("decodeADC2(% X) error = %v, want ErrChecksum", tt.frame, err)
+		}
+	}
+}
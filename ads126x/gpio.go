@@ -0,0 +1,145 @@
+package ads126x
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"periph.io/x/periph/conn/gpio"
+	"periph.io/x/periph/conn/physic"
+)
+
+//The ADS126x has eight GPIOs on the analog pins AIN3 to AIN9 and AINCOM (see section 9.3.12 of the datasheet). They are powered from the analog supply so their levels are AVDD and AVSS. They are slow since every change is an SPI transfer, but they are enough to drive excitation switches or mux enables without using pins on the Pi.
+
+//gpioBits maps the analog pins that can be GPIOs to their bit in GPIOCON, GPIODIR and GPIODAT
+var gpioBits = map[Input]byte{
+	AIN3:   GPIOCON_AIN3,
+	AIN4:   GPIOCON_AIN4,
+	AIN5:   GPIOCON_AIN5,
+	AIN6:   GPIOCON_AIN6,
+	AIN7:   GPIOCON_AIN7,
+	AIN8:   GPIOCON_AIN8,
+	AIN9:   GPIOCON_AIN9,
+	AINCOM: GPIOCON_AINCOM,
+}
+
+//ConfigureGPIO sets which analog pins are GPIOs (connect) and which of those are inputs (inputs), with one bit per pin as in GPIOCON. Pins not connected are analog pins again.
+func (d *Device) ConfigureGPIO(connect, inputs byte) error {
+	return d.WriteRegisters(GPIOCON_address, []byte{connect, inputs})
+}
+
+//ReadGPIO reads the level of every GPIO from GPIODAT, one bit per pin as in GPIOCON. The levels are not the output latch, so GPIODAT is written again by the next WriteGPIO or Out even if the level asked for is the one read.
+func (d *Device) ReadGPIO() (byte, error) {
+	data, err := d.ReadRegisters(GPIODAT_address, 1)
+	if err != nil {
+		return 0, err
+	}
+	return data[0], nil
+}
+
+//WriteGPIO sets the level of the GPIO outputs in mask to levels, one bit per pin as in GPIOCON. The other outputs keep their level.
+func (d *Device) WriteGPIO(mask, levels byte) error {
+	return d.SetField(GPIODAT_address, mask, levels&mask)
+}
+
+//GPIOPin is one of the ADS126x GPIOs. It implements periph's gpio.PinIO so it can be used wherever a pin on the Pi would be. Use Device.GPIOPin to get one.
+type GPIOPin struct {
+	dev  *Device
+	pin  Input
+	mask byte
+}
+
+var _ gpio.PinIO = &GPIOPin{}
+
+//GPIOPin returns the GPIO on an analog pin, which must be one of AIN3 to AIN9 or AINCOM. The pin stays an analog pin until In or Out is called.
+func (d *Device) GPIOPin(pin Input) (*GPIOPin, error) {
+	mask, ok := gpioBits[pin]
+	if !ok {
+		return nil, fmt.Errorf("ads126x: %v can't be a GPIO, only AIN3 to AIN9 and AINCOM can", pin)
+	}
+	return &GPIOPin{dev: d, pin: pin, mask: mask}, nil
+}
+
+//errGPIOEdge is returned by In when edge detection is asked for, which the ADS126x GPIOs don't have
+var errGPIOEdge = errors.New("ads126x: GPIOs have no edge detection")
+
+func (p *GPIOPin) String() string { return "ADS126x_" + p.pin.String() }
+
+//Halt does nothing, the pin keeps its state
+func (p *GPIOPin) Halt() error { return nil }
+
+//Name is the name of the analog pin
+func (p *GPIOPin) Name() string { return p.pin.String() }
+
+//Number is the bit of the pin in GPIOCON, GPIODIR and GPIODAT
+func (p *GPIOPin) Number() int {
+	for bit := 0; bit < 8; bit++ {
+		if p.mask == 1<<bit {
+			return bit
+		}
+	}
+	return -1
+}
+
+//Function is "In" or "Out" if the pin is a GPIO and "Analog" if it isn't, going by the shadow copy of the registers
+func (p *GPIOPin) Function() string {
+	p.dev.mu.Lock()
+	defer p.dev.mu.Unlock()
+	switch {
+	case p.dev.regs[GPIOCON_address]&p.mask == 0:
+		return "Analog"
+	case p.dev.regs[GPIODIR_address]&p.mask != 0:
+		return "In"
+	}
+	return "Out"
+}
+
+//In makes the pin a GPIO input. The ADS126x has no pull resistors or edge detection so pull must be gpio.Float or gpio.PullNoChange and edge must be gpio.NoEdge.
+func (p *GPIOPin) In(pull gpio.Pull, edge gpio.Edge) error {
+	if pull != gpio.Float && pull != gpio.PullNoChange {
+		return fmt.Errorf("ads126x: GPIOs have no pull resistors, can't set %v on %v", pull, p)
+	}
+	if edge != gpio.NoEdge {
+		return errGPIOEdge
+	}
+	return p.dev.SetFields(
+		Field{Address: GPIOCON_address, Mask: p.mask, Value: p.mask},
+		Field{Address: GPIODIR_address, Mask: p.mask, Value: p.mask},
+	)
+}
+
+//Read reads the level of the pin. If the read fails it returns gpio.Low - use Device.ReadGPIO to see the error.
+func (p *GPIOPin) Read() gpio.Level {
+	levels, err := p.dev.ReadGPIO()
+	if err != nil {
+		return gpio.Low
+	}
+	return levels&p.mask != 0
+}
+
+//WaitForEdge returns false straight away since the GPIOs have no edge detection
+func (p *GPIOPin) WaitForEdge(timeout time.Duration) bool { return false }
+
+//Pull is always gpio.Float
+func (p *GPIOPin) Pull() gpio.Pull { return gpio.Float }
+
+//DefaultPull is always gpio.Float
+func (p *GPIOPin) DefaultPull() gpio.Pull { return gpio.Float }
+
+//Out makes the pin a GPIO output at level l. The level, direction and connection are written in a single transfer.
+func (p *GPIOPin) Out(l gpio.Level) error {
+	var level byte
+	if l == gpio.High {
+		level = p.mask
+	}
+	return p.dev.SetFields(
+		Field{Address: GPIOCON_address, Mask: p.mask, Value: p.mask},
+		Field{Address: GPIODIR_address, Mask: p.mask, Value: 0},
+		Field{Address: GPIODAT_address, Mask: p.mask, Value: level},
+	)
+}
+
+//PWM is not supported by the ADS126x GPIOs
+func (p *GPIOPin) PWM(duty gpio.Duty, f physic.Frequency) error {
+	return fmt.Errorf("ads126x: %v can't do PWM", p)
+}
@@ -0,0 +1,64 @@
+package ads126x
+
+import (
+	"testing"
+
+	"periph.io/x/periph/conn/gpio"
+)
+
+func TestGPIOOutAfterReadingInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		level gpio.Level
+	}{
+		{"high", gpio.High},
+		{"low", gpio.Low},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeConn()
+			//the latch holds the opposite of the level the input reads
+			if tt.level == gpio.High {
+				fake.inputs = GPIOCON_AIN5
+			} else {
+				fake.regs[GPIODAT_address] = GPIOCON_AIN5
+			}
+			d := New(fake, Pins{})
+			pin, err := d.GPIOPin(AIN5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := pin.In(gpio.Float, gpio.NoEdge); err != nil {
+				t.Fatal(err)
+			}
+			if got := pin.Read(); got != tt.level {
+				t.Fatalf("Read() = %v, want %v", got, tt.level)
+			}
+			if err := pin.Out(tt.level); err != nil {
+				t.Fatal(err)
+			}
+			if got := fake.regs[GPIODAT_address]&GPIOCON_AIN5 != 0; got != bool(tt.level) {
+				t.Errorf("output latch is %v after Out(%v)", got, tt.level)
+			}
+			if got := pin.Function(); got != "Out" {
+				t.Errorf("Function() = %q, want Out", got)
+			}
+		})
+	}
+}
+
+func TestWriteGPIOAfterSync(t *testing.T) {
+	fake := newFakeConn()
+	//all the pins read high but the latch is low
+	fake.inputs = 0xFF
+	d := New(fake, Pins{})
+	if err := d.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.WriteGPIO(GPIOCON_AIN3, GPIOCON_AIN3); err != nil {
+		t.Fatal(err)
+	}
+	if fake.regs[GPIODAT_address]&GPIOCON_AIN3 == 0 {
+		t.Errorf("GPIODAT latch = 0x%02X after WriteGPIO, want AIN3 high", fake.regs[GPIODAT_address])
+	}
+}
@@ -0,0 +1,57 @@
+package ads126x
+
+//The ADS126x can follow the conversion data with a check byte (see section 9.4.10 of the datasheet). In checksum mode it is the sum of the data bytes plus 9Bh, which catches single bit errors. In CRC mode it is a CRC-8 of the data bytes which also catches most multi-bit errors.
+
+//crcPolynomial is the CRC-8 polynomial x^8 + x^2 + x + 1 from the datasheet, without the x^8 term
+const crcPolynomial byte = 0x07
+
+//crcTable holds the CRC of every possible byte so the CRC can be computed one byte at a time
+var crcTable = func() (table [256]byte) {
+	for i := range table {
+		crc := byte(i)
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ crcPolynomial
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+//CRC8 calculates the CRC byte the ADS126x sends in CRC mode for the given data bytes. It is the remainder of the polynomial division of the data by x^8 + x^2 + x + 1.
+func CRC8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc = crcTable[crc^b]
+	}
+	return crc
+}
+
+//Checksum calculates the check byte the ADS126x sends in checksum mode for the given data bytes
+func Checksum(data []byte) byte {
+	sum := byte(0x9B)
+	for _, b := range data {
+		sum += b
+	}
+	return sum
+}
+
+//verifyCheckByte checks the check byte received after the data bytes in the given mode
+func verifyCheckByte(mode CheckMode, data []byte, got byte) error {
+	var want byte
+	switch mode {
+	case CheckChecksum:
+		want = Checksum(data)
+	case CheckCRC:
+		want = CRC8(data)
+	default:
+		return nil
+	}
+	if got != want {
+		return &ChecksumError{Mode: mode, Got: got, Want: want}
+	}
+	return nil
+}
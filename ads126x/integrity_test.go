@@ -0,0 +1,71 @@
+package ads126x
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want byte
+	}{
+		{nil, 0x9B},
+		{[]byte{0x00, 0x00, 0x00, 0x00}, 0x9B},
+		{[]byte{0x12, 0x34, 0x56, 0x78}, 0xAF},
+		{[]byte{0xFF, 0xFF, 0xFF, 0xFF}, 0x97},
+		{[]byte{0xFF, 0xFF, 0xFE, 0x00}, 0x97},
+	}
+	for _, tt := range tests {
+		if got := Checksum(tt.data); got != tt.want {
+			t.Errorf("Checksum(% X) = 0x%02X, want 0x%02X", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestCRC8(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want byte
+	}{
+		{nil, 0x00},
+		{[]byte{0x12, 0x34, 0x56, 0x78}, 0x1C},
+		{[]byte{0x12, 0x34, 0x56, 0x79}, 0x1B},
+		{[]byte{0x7F, 0xFF, 0xFF, 0xFF}, 0xEF},
+		{[]byte{0x80, 0x00, 0x00, 0x00}, 0x31},
+		//the CRC-8 check value of "123456789" for x^8 + x^2 + x + 1
+		{[]byte("123456789"), 0xF4},
+	}
+	for _, tt := range tests {
+		if got := CRC8(tt.data); got != tt.want {
+			t.Errorf("CRC8(% X) = 0x%02X, want 0x%02X", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyCheckByte(t *testing.T) {
+	data := []byte{0x12, 0x34, 0x56, 0x78}
+	tests := []struct {
+		name    string
+		mode    CheckMode
+		got     byte
+		wantErr bool
+	}{
+		{"disabled", CheckDisabled, 0x00, false},
+		{"checksum", CheckChecksum, 0xAF, false},
+		{"bad checksum", CheckChecksum, 0x1C, true},
+		{"crc", CheckCRC, 0x1C, false},
+		{"bad crc", CheckCRC, 0xAF, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyCheckByte(tt.mode, data, tt.got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyCheckByte() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrChecksum) {
+				t.Errorf("verifyCheckByte() error = %v, want ErrChecksum", err)
+			}
+		})
+	}
+}
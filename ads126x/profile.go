@@ -0,0 +1,201 @@
+package ads126x
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+//The calibration registers are cleared on every power cycle. A calibration profile saves them to a JSON file along with what they were taken with - the board (ID register and a serial chosen by the user), the channel settings, the internal temperature and the time - so they can be written back at startup instead of calibrating again.
+
+//CalibrationProfile is a saved ADC1 calibration for one channel of one board
+type CalibrationProfile struct {
+	//ID is the ID register of the ADC (device and revision)
+	ID byte
+	//Serial identifies the board. It is chosen by the user as the ADC has no serial number of its own.
+	Serial string
+	//Channel is the inputs, gain and data rate the calibration was taken with. The calibration is only valid for these settings.
+	Channel     ChannelConfig
+	Calibration Calibration
+	//Temperature is the die temperature of the ADC in °C when the calibration was taken, as measured by its internal temperature sensor with Device.ReadTemperature
+	Temperature float64
+	//Time is when the calibration was taken
+	Time time.Time
+}
+
+//matches is true if the profile is for the given board and channel
+func (p CalibrationProfile) matches(id byte, serial string, channel ChannelConfig) bool {
+	return p.ID == id && p.Serial == serial && p.Channel == channel
+}
+
+//ProfileStore is a set of calibration profiles, at most one per board and channel, kept in a JSON file
+type ProfileStore struct {
+	Profiles []CalibrationProfile
+}
+
+//LoadProfiles reads the calibration profiles from a JSON file. A file that does not exist yet gives an empty store.
+func LoadProfiles(path string) (*ProfileStore, error) {
+	store := &ProfileStore{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("ads126x: reading calibration profiles from %s: %w", path, err)
+	}
+	return store, nil
+}
+
+//Save writes the calibration profiles to a JSON file. It writes to a temporary file first and renames it so the old file is not lost if the program is stopped part way through. A file that is replaced keeps its permissions, a new one is made readable by everyone (0644).
+func (s *ProfileStore) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	//keep the mode of the file being replaced, CreateTemp makes the file readable by the owner only
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+//Put adds a profile to the store, replacing any older profile for the same board and channel
+func (s *ProfileStore) Put(profile CalibrationProfile) {
+	for i, p := range s.Profiles {
+		if p.matches(profile.ID, profile.Serial, profile.Channel) {
+			s.Profiles[i] = profile
+			return
+		}
+	}
+	s.Profiles = append(s.Profiles, profile)
+}
+
+//Find returns the profile for the given board and channel
+func (s *ProfileStore) Find(id byte, serial string, channel ChannelConfig) (CalibrationProfile, bool) {
+	for _, p := range s.Profiles {
+		if p.matches(id, serial, channel) {
+			return p, true
+		}
+	}
+	return CalibrationProfile{}, false
+}
+
+//ProfileCheck sets when applying a calibration profile gives warnings. A zero MaxAge or MaxTemperatureDelta turns that check off.
+type ProfileCheck struct {
+	//MaxAge is how old a profile can be before it is stale
+	MaxAge time.Duration
+	//MaxTemperatureDelta is how far in °C the current temperature can be from the temperature the profile was taken at
+	MaxTemperatureDelta float64
+	//Temperature is the current die temperature in °C, measured the same way as the profile's, with Device.ReadTemperature. It is only used if HasTemperature is true.
+	Temperature    float64
+	HasTemperature bool
+}
+
+//warnings checks the profile and returns a warning error for each check it fails
+func (c ProfileCheck) warnings(p CalibrationProfile, now time.Time) []error {
+	var warnings []error
+	if age := now.Sub(p.Time); c.MaxAge > 0 && age > c.MaxAge {
+		warnings = append(warnings, fmt.Errorf("%w: taken %v ago, the limit is %v", ErrStaleProfile, age.Round(time.Second), c.MaxAge))
+	}
+	if delta := math.Abs(c.Temperature - p.Temperature); c.HasTemperature && c.MaxTemperatureDelta > 0 && delta > c.MaxTemperatureDelta {
+		warnings = append(warnings, fmt.Errorf("%w: taken at %.1f °C, now %.1f °C", ErrProfileTemperature, p.Temperature, c.Temperature))
+	}
+	return warnings
+}
+
+//ReadID reads the ID register, which holds the device (ADS1262 or ADS1263) and revision
+func (d *Device) ReadID() (byte, error) {
+	id, err := d.ReadRegisters(ID_address, 1)
+	if err != nil {
+		return 0, err
+	}
+	return id[0], nil
+}
+
+//NewCalibrationProfile makes a profile from the calibration registers as they are now, for example straight after calibrating. temperature is the die temperature in °C at the time of the calibration from the internal temperature sensor - call ReadTemperature just before or after calibrating, since it changes the channel settings while it reads. The offset and gain of the ADC drift with this temperature, which is why ApplyProfile can warn when it has moved too far.
+func (d *Device) NewCalibrationProfile(serial string, channel ChannelConfig, temperature float64) (CalibrationProfile, error) {
+	id, err := d.ReadID()
+	if err != nil {
+		return CalibrationProfile{}, err
+	}
+	cal, err := d.ReadCalibration()
+	if err != nil {
+		return CalibrationProfile{}, err
+	}
+	return CalibrationProfile{
+		ID:          id,
+		Serial:      serial,
+		Channel:     channel,
+		Calibration: cal,
+		Temperature: temperature,
+		Time:        time.Now(),
+	}, nil
+}
+
+//ApplyProfile finds the profile for this board and channel, sets up the channel and writes the saved calibration. It returns ErrNoProfile if there is none. The profile is still applied if it fails the checks - the warnings returned say why it should not be trusted (ErrStaleProfile or ErrProfileTemperature). For the temperature check, read the die temperature with ReadTemperature before applying the profile and pass it in check.Temperature.
+func (d *Device) ApplyProfile(store *ProfileStore, serial string, channel ChannelConfig, check ProfileCheck) (warnings []error, err error) {
+	id, err := d.ReadID()
+	if err != nil {
+		return nil, err
+	}
+	profile, ok := store.Find(id, serial, channel)
+	if !ok {
+		return nil, fmt.Errorf("%w: ID 0x%02X, serial %q", ErrNoProfile, id, serial)
+	}
+	if err := d.ApplyChannel(profile.Channel); err != nil {
+		return nil, err
+	}
+	if err := d.WriteCalibration(profile.Calibration); err != nil {
+		return nil, err
+	}
+	return check.warnings(profile, time.Now()), nil
+}
+
+//LoadProfile reads the calibration profiles from a JSON file and applies the one for this board and channel, as ApplyProfile does
+func (d *Device) LoadProfile(path string, serial string, channel ChannelConfig, check ProfileCheck) (warnings []error, err error) {
+	store, err := LoadProfiles(path)
+	if err != nil {
+		return nil, err
+	}
+	return d.ApplyProfile(store, serial, channel, check)
+}
+
+//SaveProfile adds a profile of the current calibration to a JSON file, replacing the old one for this board and channel. temperature is the die temperature at the time of the calibration, from ReadTemperature, as for NewCalibrationProfile.
+func (d *Device) SaveProfile(path string, serial string, channel ChannelConfig, temperature float64) error {
+	profile, err := d.NewCalibrationProfile(serial, channel, temperature)
+	if err != nil {
+		return err
+	}
+	store, err := LoadProfiles(path)
+	if err != nil {
+		return err
+	}
+	store.Put(profile)
+	return store.Save(path)
+}
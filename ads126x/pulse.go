@@ -0,0 +1,98 @@
+package ads126x
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+//In pulse (one shot) mode, set with MODE0_runmode_pulse or Mode0{RunMode: RunPulse}, ADC1 does a single conversion each time it is started and then stops. This avoids the settling problems of switching inputs while converting continuously and suits sensors that only need a reading every few seconds.
+
+//ChannelConfig selects and scales an ADC1 measurement - the inputs in INPMUX and the PGA and data rate in MODE2
+type ChannelConfig struct {
+	Mux   InpMux
+	Mode2 Mode2
+}
+
+//ReadOnce triggers a single ADC1 conversion with the START pin or the START1 opcode, waits for it to finish and returns the result. ADC1 should be in pulse mode (see SetRunMode). With a DRDY pin the end of the conversion is signalled by DRDY, without one the status byte is polled. A zero timeout uses the timeout of the device (see SetTimeout) and a negative timeout waits forever.
+func (d *Device) ReadOnce(timeout time.Duration) (Sample, error) {
+	if timeout == 0 {
+		timeout = d.Timeout()
+	}
+	//make sure there is a fresh start edge
+	if err := d.Stop(); err != nil {
+		return Sample{}, err
+	}
+	if err := d.clearNewData(); err != nil {
+		return Sample{}, err
+	}
+	if err := d.Start(); err != nil {
+		return Sample{}, err
+	}
+	var sample Sample
+	var err error
+	if d.pins.Drdy != nil {
+		if err = d.waitDRDY(context.Background(), timeout); err == nil {
+			sample, err = d.applyClipPolicy(d.readDirect())
+		}
+	} else {
+		sample, err = d.ReadNextByCommand(timeout)
+	}
+	if stoperr := d.Stop(); err == nil {
+		err = stoperr
+	}
+	return sample, err
+}
+
+//clearNewData makes sure only a conversion that finishes after it is seen as new. With a DRDY pin it throws away any edge left over from earlier conversions, without one it reads the data once to clear the new data bit in the status byte.
+func (d *Device) clearNewData() error {
+	if d.pins.Drdy != nil {
+		for d.pins.Drdy.WaitForEdge(0) {
+		}
+		return nil
+	}
+	if _, err := d.readSampleByCommand(); err != nil && !errors.Is(err, ErrChecksum) {
+		return err
+	}
+	return nil
+}
+
+//SetRunMode switches ADC1 between continuous and pulse (one shot) conversions. The other MODE0 settings are kept. Conversions should be stopped.
+func (d *Device) SetRunMode(mode RunMode) error {
+	var mode0 Mode0
+	if err := d.ReadConfig(&mode0); err != nil {
+		return err
+	}
+	if mode0.RunMode == mode {
+		return nil
+	}
+	mode0.RunMode = mode
+	return d.WriteConfig(mode0)
+}
+
+//ApplyChannel stops conversions and writes the MODE2 and INPMUX registers of the channel in a single transfer
+func (d *Device) ApplyChannel(channel ChannelConfig) error {
+	mode2, err := channel.Mode2.Encode()
+	if err != nil {
+		return err
+	}
+	mux, err := channel.Mux.Encode()
+	if err != nil {
+		return err
+	}
+	if err := d.Stop(); err != nil {
+		return err
+	}
+	return d.WriteRegisters(MODE2_address, []byte{mode2, mux})
+}
+
+//MeasureOnce applies the channel settings, puts ADC1 in pulse mode and takes a single conversion. See ReadOnce.
+func (d *Device) MeasureOnce(channel ChannelConfig, timeout time.Duration) (Sample, error) {
+	if err := d.ApplyChannel(channel); err != nil {
+		return Sample{}, err
+	}
+	if err := d.SetRunMode(RunPulse); err != nil {
+		return Sample{}, err
+	}
+	return d.ReadOnce(timeout)
+}
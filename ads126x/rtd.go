@@ -0,0 +1,237 @@
+package ads126x
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+//An RTD is measured ratiometrically (see TI application note SBAA275). An IDAC drives a current through the RTD and then through a reference resistor, and the voltage across the reference resistor is used as the ADC reference through REFMUX. The current cancels out, so the result only depends on the reference resistor: R = code / 2^31 * Rref / gain. With 3-wire RTDs a second IDAC drives the same current through the third lead so the lead resistances cancel, and both currents flow through the reference resistor. IDAC rotation (chop mode in MODE0) swaps the two IDACs on alternate conversions to cancel the mismatch between them.
+
+//RTDWiring is the number of wires an RTD is connected with
+type RTDWiring byte
+
+const (
+	//RTD2Wire includes the resistance of both leads in the result
+	RTD2Wire RTDWiring = iota
+	//RTD3Wire uses a second IDAC to cancel the lead resistance, assuming both leads are equal
+	RTD3Wire
+	//RTD4Wire senses the RTD with separate leads so no current flows in them
+	RTD4Wire
+)
+
+//Nominal resistances at 0 °C of common platinum RTDs
+const (
+	PT100  = 100.0
+	PT1000 = 1000.0
+)
+
+//CallendarVanDusen holds the coefficients of the Callendar–Van Dusen equation R(T) = R0 (1 + A T + B T² + C (T - 100) T³), where the C term is only used below 0 °C
+type CallendarVanDusen struct {
+	A, B, C float64
+}
+
+//IEC60751 are the Callendar–Van Dusen coefficients of IEC 60751 for platinum RTDs with α = 0.00385
+var IEC60751 = CallendarVanDusen{A: 3.9083e-3, B: -5.775e-7, C: -4.183e-12}
+
+//Resistance is the resistance in ohms at temperature t in °C of an RTD with resistance r0 at 0 °C
+func (cvd CallendarVanDusen) Resistance(t, r0 float64) float64 {
+	r := 1 + cvd.A*t + cvd.B*t*t
+	if t < 0 {
+		r += cvd.C * (t - 100) * t * t * t
+	}
+	return r0 * r
+}
+
+//Temperature is the temperature in °C of an RTD with resistance r0 at 0 °C that has resistance r ohms. Above 0 °C the quadratic is solved directly, below it is refined with Newton's method.
+func (cvd CallendarVanDusen) Temperature(r, r0 float64) float64 {
+	t := (-cvd.A + math.Sqrt(cvd.A*cvd.A-4*cvd.B*(1-r/r0))) / (2 * cvd.B)
+	if r >= r0 || cvd.C == 0 {
+		return t
+	}
+	for i := 0; i < 10; i++ {
+		f := cvd.Resistance(t, r0) - r
+		df := r0 * (cvd.A + 2*cvd.B*t + cvd.C*(4*t*t*t-300*t*t))
+		step := f / df
+		t -= step
+		if math.Abs(step) < 1e-9 {
+			break
+		}
+	}
+	return t
+}
+
+//RTD describes an RTD connected to the ADC and how to measure it
+type RTD struct {
+	Wiring RTDWiring
+	//R0 is the resistance at 0 °C, for example PT100 or PT1000
+	R0 float64
+	//Coefficients are the Callendar–Van Dusen coefficients. The zero value uses IEC60751.
+	Coefficients CallendarVanDusen
+	//Positive and Negative are the ADC inputs across the RTD
+	Positive Input
+	Negative Input
+	//Excitation is the pin IDAC1 drives the RTD from
+	Excitation Input
+	//LeadCompensation is the pin IDAC2 drives the third lead from. It is only used with RTD3Wire.
+	LeadCompensation Input
+	//Current is the IDAC current
+	Current IDACCurrent
+	//ReferenceResistor is the resistance in ohms of the reference resistor
+	ReferenceResistor float64
+	//Ref selects the reference inputs across the reference resistor
+	Ref    RefMux
+	Gain   Gain
+	Rate   DataRate
+	Filter Filter
+	//Rotation swaps IDAC1 and IDAC2 on alternate conversions. It is only used with RTD3Wire.
+	Rotation bool
+	//Chop swaps the inputs on alternate conversions to cancel the ADC offset
+	Chop bool
+}
+
+//coefficients is the Callendar–Van Dusen coefficients to use
+func (r RTD) coefficients() CallendarVanDusen {
+	if r.Coefficients == (CallendarVanDusen{}) {
+		return IEC60751
+	}
+	return r.Coefficients
+}
+
+//check returns an error if the RTD settings can't work
+func (r RTD) check() error {
+	switch {
+	case r.Wiring > RTD4Wire:
+		return fmt.Errorf("ads126x: RTD wiring %d is not 2, 3 or 4 wire", r.Wiring)
+	case r.R0 <= 0:
+		return fmt.Errorf("ads126x: RTD R0 must be positive, not %v", r.R0)
+	case r.ReferenceResistor <= 0:
+		return fmt.Errorf("ads126x: RTD reference resistor must be positive, not %v", r.ReferenceResistor)
+	case r.Current == IDACOff:
+		return errors.New("ads126x: RTD needs an IDAC current")
+	case r.Rotation && r.Wiring != RTD3Wire:
+		return errors.New("ads126x: IDAC rotation needs two IDACs, which only 3-wire RTDs use")
+	}
+	return nil
+}
+
+//ScanChannel is the channel that measures the RTD, for use with a Scanner. MODE0 is shared by all channels so chop and IDAC rotation have to be set separately (see RTD.Mode0).
+func (r RTD) ScanChannel(name string) (ScanChannel, error) {
+	if err := r.check(); err != nil {
+		return ScanChannel{}, err
+	}
+	c := ScanChannel{
+		Name: name,
+		ChannelConfig: ChannelConfig{
+			Mux:   InpMux{Positive: r.Positive, Negative: r.Negative},
+			Mode2: Mode2{Gain: r.Gain, Rate: r.Rate},
+		},
+		Mode1:   Mode1{Filter: r.Filter},
+		IDACMux: IDACMux{IDAC1: r.Excitation, IDAC2: Float},
+		IDACMag: IDACMag{IDAC1: r.Current},
+		Ref:     r.Ref,
+		//the IDAC current and the reference it sets up need a conversion to settle
+		Discard: 1,
+	}
+	if r.Wiring == RTD3Wire {
+		c.IDACMux.IDAC2 = r.LeadCompensation
+		c.IDACMag.IDAC2 = r.Current
+	}
+	return c, nil
+}
+
+//Mode0 sets the chop and IDAC rotation of the RTD in a MODE0 setting
+func (r RTD) Mode0(mode0 Mode0) Mode0 {
+	switch {
+	case r.Chop && r.Rotation:
+		mode0.Chop = ChopInputAndIDACRotation
+	case r.Chop:
+		mode0.Chop = ChopInput
+	case r.Rotation:
+		mode0.Chop = ChopIDACRotation
+	default:
+		mode0.Chop = ChopDisabled
+	}
+	return mode0
+}
+
+//Resistance converts ADC1 conversion data taken with the RTD settings into the RTD resistance in ohms
+func (r RTD) Resistance(code int32) float64 {
+	rref := r.ReferenceResistor
+	if r.Wiring == RTD3Wire {
+		//both IDAC currents flow through the reference resistor
+		rref *= 2
+	}
+	return float64(code) / (1 << 31) * rref / float64(r.Gain.Factor())
+}
+
+//Temperature converts ADC1 conversion data taken with the RTD settings into the temperature in °C
+func (r RTD) Temperature(code int32) float64 {
+	return r.coefficients().Temperature(r.Resistance(code), r.R0)
+}
+
+//RTDReading is an RTD measurement
+type RTDReading struct {
+	Sample
+	//Ohms is the RTD resistance
+	Ohms float64
+	//Celsius is the RTD temperature in °C
+	Celsius float64
+}
+
+//ConfigureRTD writes the registers for measuring the RTD - the inputs, IDACs, reference, gain, data rate, filter, chop and IDAC rotation. The internal reference is switched on since the IDACs need it.
+func (d *Device) ConfigureRTD(rtd RTD) error {
+	channel, err := rtd.ScanChannel("")
+	if err != nil {
+		return err
+	}
+	return d.UpdateConfig(func(c *Config) {
+		c.Power.InternalRef = true
+		c.Mode0 = rtd.Mode0(c.Mode0)
+		c.Mode1.Filter = channel.Mode1.Filter
+		c.Mode2 = channel.Mode2
+		c.InpMux = channel.Mux
+		c.IDACMux = channel.IDACMux
+		c.IDACMag = channel.IDACMag
+		c.RefMux = channel.Ref
+	})
+}
+
+//MeasureRTD configures the ADC for the RTD, starts conversions, throws away the first one while the excitation settles and converts the next one to a temperature. Conversions are stopped afterwards. The IDACs are left on - write IDACMag{} to switch them off.
+func (d *Device) MeasureRTD(ctx context.Context, rtd RTD) (RTDReading, error) {
+	if err := d.Stop(); err != nil {
+		return RTDReading{}, err
+	}
+	if err := d.ConfigureRTD(rtd); err != nil {
+		return RTDReading{}, err
+	}
+	if err := d.SetRunMode(RunContinuous); err != nil {
+		return RTDReading{}, err
+	}
+	if err := d.clearNewData(); err != nil {
+		return RTDReading{}, err
+	}
+	if err := d.Start(); err != nil {
+		return RTDReading{}, err
+	}
+	read := d.ReadContext
+	if d.pins.Drdy == nil {
+		read = d.ReadNextByCommandContext
+	}
+	var sample Sample
+	var err error
+	for i := 0; i < 2; i++ {
+		if sample, err = read(ctx); err != nil && !errors.Is(err, ErrClipped) {
+			break
+		}
+	}
+	if stoperr := d.Stop(); err == nil {
+		err = stoperr
+	}
+	if err != nil {
+		return RTDReading{Sample: sample}, err
+	}
+	ohms := rtd.Resistance(sample.Raw)
+	return RTDReading{Sample: sample, Ohms: ohms, Celsius: rtd.coefficients().Temperature(ohms, rtd.R0)}, nil
+}
@@ -0,0 +1,71 @@
+package ads126x
+
+import (
+	"math"
+	"testing"
+)
+
+//cvdPoints are points of the IEC 60751 PT100 table worked out from the Callendar–Van Dusen equation
+var cvdPoints = []struct {
+	celsius float64
+	ohms    float64
+}{
+	{-200, 18.52008},
+	{-100, 60.25584},
+	{-40, 84.270652},
+	{-0.5, 99.80457},
+	{0, 100},
+	{0.5, 100.19540},
+	{25, 109.734656},
+	{100, 138.5055},
+	{200, 175.856},
+	{850, 390.481125},
+}
+
+func TestCallendarVanDusenResistance(t *testing.T) {
+	for _, p := range cvdPoints {
+		if got := IEC60751.Resistance(p.celsius, PT100); math.Abs(got-p.ohms) > 1e-5 {
+			t.Errorf("Resistance(%v, PT100) = %.6f, want %.6f", p.celsius, got, p.ohms)
+		}
+		if got := IEC60751.Resistance(p.celsius, PT1000); math.Abs(got-10*p.ohms) > 1e-4 {
+			t.Errorf("Resistance(%v, PT1000) = %.5f, want %.5f", p.celsius, got, 10*p.ohms)
+		}
+	}
+}
+
+func TestCallendarVanDusenTemperature(t *testing.T) {
+	for _, p := range cvdPoints {
+		if got := IEC60751.Temperature(p.ohms, PT100); math.Abs(got-p.celsius) > 1e-4 {
+			t.Errorf("Temperature(%v, PT100) = %.6f, want %v", p.ohms, got, p.celsius)
+		}
+	}
+	//the inverse has to undo Resistance over the whole range, on both sides of 0 °C
+	for celsius := -200.0; celsius <= 850; celsius += 12.5 {
+		r := IEC60751.Resistance(celsius, PT1000)
+		if got := IEC60751.Temperature(r, PT1000); math.Abs(got-celsius) > 1e-6 {
+			t.Errorf("Temperature(Resistance(%v)) = %.9f", celsius, got)
+		}
+	}
+}
+
+func TestRTDResistance(t *testing.T) {
+	tests := []struct {
+		name string
+		rtd  RTD
+		code int32
+		want float64
+	}{
+		{"2-wire", RTD{Wiring: RTD2Wire, ReferenceResistor: 4000, Gain: Gain8}, 1 << 28, 62.5},
+		{"3-wire", RTD{Wiring: RTD3Wire, ReferenceResistor: 4000, Gain: Gain8}, 1 << 28, 125},
+		{"4-wire", RTD{Wiring: RTD4Wire, ReferenceResistor: 1600, Gain: Gain4}, 1 << 29, 100},
+	}
+	for _, tt := range tests {
+		if got := tt.rtd.Resistance(tt.code); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: Resistance(%d) = %v, want %v", tt.name, tt.code, got, tt.want)
+		}
+	}
+	rtd := RTD{Wiring: RTD4Wire, R0: PT100, ReferenceResistor: 1600, Gain: Gain4}
+	if got := rtd.Temperature(1 << 29); math.Abs(got) > 1e-9 {
+		t.Errorf("Temperature of 100 ohm = %v, want 0", got)
+	}
+}
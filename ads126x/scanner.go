@@ -0,0 +1,205 @@
+package ads126x
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//A scan reads a list of ADC1 channels one after the other, each with its own inputs, gain, data rate, filter, excitation currents and reference. Only the registers that differ from the previous channel are written. Conversions are stopped while they are written and restarted afterwards, so the first conversion of each channel starts with a freshly reset filter and is settled. The reads wait for it as long as the settling model says it takes (see Settling), so no fixed sleeps are needed. Channels that need the analog side to settle as well, for example after switching on an excitation current or a different reference, can throw away more conversions with Discard.
+
+//ScanChannel is one channel of a scan
+type ScanChannel struct {
+	//Name tags the samples read from this channel
+	Name string
+	//ChannelConfig is the inputs (INPMUX) and the PGA and data rate (MODE2)
+	ChannelConfig
+	//Mode1 is the digital filter and sensor bias. The zero value is the sinc1 filter with no sensor bias.
+	Mode1 Mode1
+	//IDACMux and IDACMag set the excitation currents. The zero value has both currents off (IDACMag is what switches them off).
+	IDACMux IDACMux
+	IDACMag IDACMag
+	//Ref is the reference. The zero value is the internal 2.5 V reference.
+	Ref RefMux
+	//VBias enables the level shift voltage on AINCOM (in the POWER register) while the channel is read, for example for a thermocouple that isn't grounded. The other POWER settings are left as they are.
+	VBias bool
+	//Discard is the number of conversions thrown away after switching to this channel, on top of the ones the filter needs to settle
+	Discard int
+}
+
+//registers encodes the settings of the channel
+func (c ScanChannel) registers() (map[byte]byte, error) {
+	values := make(map[byte]byte)
+	for _, config := range []RegisterConfig{c.Mode1, c.Mode2, c.Mux, c.IDACMux, c.IDACMag, c.Ref} {
+		value, err := config.Encode()
+		if err != nil {
+			return nil, fmt.Errorf("ads126x: scan channel %q: %w", c.Name, err)
+		}
+		values[config.Address()] = value
+	}
+	return values, nil
+}
+
+//TaggedSample is a sample read during a scan, tagged with the channel it came from
+type TaggedSample struct {
+	Sample
+	//Channel is the name of the channel
+	Channel string
+	//Index is the position of the channel in the scan
+	Index int
+	//Time is when the sample was read
+	Time time.Time
+}
+
+//Scanner reads a list of channels in order, over and over. Use NewScanner to create one.
+type Scanner struct {
+	dev       *Device
+	channels  []ScanChannel
+	registers []map[byte]byte
+	next      int
+	applied   bool
+}
+
+//NewScanner creates a Scanner for the channels, which are read in the order given. Channel names must be unique. ADC1 is put in continuous mode.
+func NewScanner(dev *Device, channels []ScanChannel) (*Scanner, error) {
+	if len(channels) == 0 {
+		return nil, errors.New("ads126x: a scan needs at least one channel")
+	}
+	s := &Scanner{dev: dev, channels: channels}
+	names := make(map[string]bool)
+	for _, c := range channels {
+		if names[c.Name] {
+			return nil, fmt.Errorf("ads126x: scan channel name %q is used twice", c.Name)
+		}
+		names[c.Name] = true
+		values, err := c.registers()
+		if err != nil {
+			return nil, err
+		}
+		s.registers = append(s.registers, values)
+	}
+	if err := dev.SetRunMode(RunContinuous); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+//Settling works out the settling of the channel at index with the MODE0 settings currently on the device
+func (s *Scanner) Settling(index int) Settling {
+	d := s.dev
+	d.mu.Lock()
+	var mode0 Mode0
+	mode0.Decode(d.regs[MODE0_address])
+	d.mu.Unlock()
+	c := s.channels[index]
+	return SettlingFor(mode0, c.Mode1, c.Mode2)
+}
+
+//CycleTime is about how long it takes to read every channel once. Each channel takes the first conversion latency from the datasheet table plus the conversions it throws away.
+func (s *Scanner) CycleTime() time.Duration {
+	var total time.Duration
+	for i, c := range s.channels {
+		settling := s.Settling(i)
+		total += settling.FirstConversion + time.Duration(float64(c.Discard)*float64(time.Second)/settling.Rate)
+	}
+	return total
+}
+
+//Channels returns the channels of the scan
+func (s *Scanner) Channels() []ScanChannel {
+	return s.channels
+}
+
+//apply switches the ADC to the channel at index. Conversions are stopped while the changed registers are written and then restarted so the filter starts afresh. It returns the number of conversions to throw away.
+func (s *Scanner) apply(index int) (int, error) {
+	d := s.dev
+	if err := d.Stop(); err != nil {
+		return 0, err
+	}
+	values := make(map[byte]byte, len(s.registers[index])+1)
+	for reg, value := range s.registers[index] {
+		values[reg] = value
+	}
+	d.mu.Lock()
+	values[POWER_address] = d.regs[POWER_address]&^POWER_vbias_mask | boolBit(s.channels[index].VBias, POWER_vbias_mask)
+	d.mu.Unlock()
+	if _, err := d.writeChanged(values); err != nil {
+		return 0, err
+	}
+	if err := d.clearNewData(); err != nil {
+		return 0, err
+	}
+	if err := d.Start(); err != nil {
+		return 0, err
+	}
+	return s.channels[index].Discard, nil
+}
+
+//Next reads the next channel of the scan. If the read fails the same channel is read again on the next call.
+func (s *Scanner) Next(ctx context.Context) (TaggedSample, error) {
+	index := s.next
+	//a scan of one channel keeps converting without being restarted
+	if !s.applied || len(s.channels) > 1 {
+		discard, err := s.apply(index)
+		if err != nil {
+			return TaggedSample{}, err
+		}
+		s.applied = true
+		for i := 0; i < discard; i++ {
+			if _, err := s.read(ctx); err != nil && !errors.Is(err, ErrChecksum) && !errors.Is(err, ErrClipped) {
+				return TaggedSample{}, err
+			}
+		}
+	}
+	sample, err := s.read(ctx)
+	if err != nil && !errors.Is(err, ErrClipped) {
+		//make sure the channel is set up again when it is retried
+		s.applied = false
+		return TaggedSample{}, err
+	}
+	s.next = (index + 1) % len(s.channels)
+	return TaggedSample{Sample: sample, Channel: s.channels[index].Name, Index: index, Time: time.Now()}, err
+}
+
+//read reads the next conversion, waiting on DRDY if the device has a DRDY pin and polling the status byte otherwise
+func (s *Scanner) read(ctx context.Context) (Sample, error) {
+	if s.dev.pins.Drdy == nil {
+		return s.dev.ReadNextByCommandContext(ctx)
+	}
+	return s.dev.ReadContext(ctx)
+}
+
+//Scan reads every channel once, starting from the first
+func (s *Scanner) Scan(ctx context.Context) ([]TaggedSample, error) {
+	s.next = 0
+	samples := make([]TaggedSample, 0, len(s.channels))
+	for range s.channels {
+		sample, err := s.Next(ctx)
+		if err != nil {
+			return samples, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+//Run scans the channels over and over and sends the samples to samples until ctx is done, in which case it returns the context's error. Conversions are stopped afterwards. Like Stream it skips samples that fail the checksum, or are clipped when the clip policy is ClipDrop, and ends on any other error.
+func (s *Scanner) Run(ctx context.Context, samples chan<- TaggedSample) error {
+	//the scan always ends with an error so a failure to stop on top of it is not reported
+	defer s.dev.Stop()
+	for {
+		sample, err := s.Next(ctx)
+		if errors.Is(err, ErrChecksum) || errors.Is(err, ErrClipped) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case samples <- sample:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package ads126x
+
+import "testing"
+
+func TestScannerVBias(t *testing.T) {
+	fake := newFakeConn()
+	d := New(fake, Pins{})
+	if err := d.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	thermocouple := ScanChannel{Name: "thermocouple", ChannelConfig: ChannelConfig{Mux: InpMux{Positive: AIN9, Negative: AINCOM}}, VBias: true}
+	bridge := ScanChannel{Name: "bridge", ChannelConfig: ChannelConfig{Mux: InpMux{Positive: AIN0, Negative: AIN1}}}
+	s, err := NewScanner(d, []ScanChannel{thermocouple, bridge})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []bool{true, false, true} {
+		if _, err := s.apply(i % 2); err != nil {
+			t.Fatal(err)
+		}
+		if got := fake.regs[POWER_address]&POWER_vbias_mask != 0; got != want {
+			t.Errorf("channel %d: VBIAS is %v, want %v", i%2, got, want)
+		}
+		//the internal reference stays on
+		if fake.regs[POWER_address]&POWER_intref_mask == 0 {
+			t.Errorf("channel %d switched the internal reference off", i%2)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ads126x
+
+import "time"
+
+//The digital filter needs time to settle (see section 9.4.3 of the datasheet). After conversions are started, or restarted by writing a MODE or INPMUX register, the filter is reset and the first conversion is held back until it is settled. The sincN filters take N conversion periods for this and the FIR filter takes one. On top of that there is a delay through the modulator and the first filter stage, and the conversion delay set in MODE0. The datasheet gives the first conversion latency in a table for each data rate and filter, which is used here. If the input changes while the ADC keeps converting the filter is not reset, so the conversions that overlap the change have to be thrown away instead.
+//In chop mode each result is the average of two conversions with the inputs (or the IDACs) swapped, and the conversion delay is inserted before each of them, so results come at half the data rate or less.
+
+//latencyTable is the first conversion latency in ms from the datasheet for each data rate, with chop off and no conversion delay. The columns are sinc1, sinc2, sinc3, sinc4 and FIR. The FIR filter only works at 2.5, 5, 10 and 20 SPS so its other entries are zero.
+var latencyTable = [...][5]float64{
+	Rate2_5:   {400.4, 800.4, 1200, 1600, 402.2},
+	Rate5:     {200.4, 400.4, 600.4, 800.4, 202.2},
+	Rate10:    {100.4, 200.4, 300.4, 400.4, 102.2},
+	Rate16_6:  {60.35, 120.4, 180.4, 240.4, 0},
+	Rate20:    {50.35, 100.4, 150.4, 200.4, 52.22},
+	Rate50:    {20.35, 40.35, 60.35, 80.35, 0},
+	Rate60:    {17.02, 33.69, 50.35, 67.02, 0},
+	Rate100:   {10.35, 20.35, 30.35, 40.35, 0},
+	Rate400:   {2.855, 5.355, 7.855, 10.36, 0},
+	Rate1200:  {1.188, 2.022, 2.855, 3.688, 0},
+	Rate2400:  {0.771, 1.188, 1.605, 2.022, 0},
+	Rate4800:  {0.563, 0.771, 0.980, 1.188, 0},
+	Rate7200:  {0.494, 0.633, 0.772, 0.911, 0},
+	Rate14400: {0.424, 0.494, 0.563, 0.633, 0},
+	Rate19200: {0.337, 0.389, 0.441, 0.493, 0},
+	Rate38400: {0.207, 0.233, 0.259, 0.285, 0},
+}
+
+//latencyAllowance is a generous allowance for the delay through the modulator and the first filter stage. It is only used for settings the table doesn't cover, like the FIR filter at a data rate it doesn't support.
+const latencyAllowance = 1 * time.Millisecond
+
+//Settling describes how long conversions take to settle with a given configuration
+type Settling struct {
+	//FirstConversion is the time from starting conversions to the first data, which is settled
+	FirstConversion time.Duration
+	//Settled is the longest time from an input change to settled data when conversions keep running
+	Settled time.Duration
+	//Discard is the number of results to throw away after an input change when conversions keep running. The next one is settled.
+	Discard int
+	//Rate is the effective output data rate in samples per second
+	Rate float64
+}
+
+//filterOrder is the number of conversion periods the filter needs to settle
+func filterOrder(filter Filter) int {
+	if filter >= FilterFIR {
+		return 1
+	}
+	return int(filter) + 1
+}
+
+//firstConversion is the first conversion latency with chop off and no conversion delay. It comes from the datasheet table, or for settings the table doesn't cover it is a bound of one conversion period more than the filter needs plus latencyAllowance.
+func firstConversion(filter Filter, rate DataRate, period time.Duration) time.Duration {
+	if int(rate) < len(latencyTable) && filter <= FilterFIR {
+		if ms := latencyTable[rate][filter]; ms != 0 {
+			return time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+	return time.Duration(filterOrder(filter)+1)*period + latencyAllowance
+}
+
+//SettlingFor works out the settling of the given MODE0, MODE1 and MODE2 settings
+func SettlingFor(mode0 Mode0, mode1 Mode1, mode2 Mode2) Settling {
+	period := time.Duration(float64(time.Second) / mode2.Rate.SPS())
+	order := filterOrder(mode1.Filter)
+	delay := mode0.Delay.Duration()
+	first := firstConversion(mode1.Filter, mode2.Rate, period) + delay
+	output := period
+	if mode0.Chop != ChopDisabled {
+		//both halves of the chop cycle have to settle
+		first *= 2
+		output = 2 * (period + delay)
+	}
+	//an input change part way through a result affects that result and the next order-1 ones
+	return Settling{
+		FirstConversion: first,
+		Settled:         time.Duration(order+1) * output,
+		Discard:         order,
+		Rate:            float64(time.Second) / float64(output),
+	}
+}
+
+//Settling works out the settling of the current MODE0, MODE1 and MODE2 settings
+func (d *Device) Settling() Settling {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.settling()
+}
+
+//settling is Settling with d.mu held
+func (d *Device) settling() Settling {
+	var mode0 Mode0
+	var mode1 Mode1
+	var mode2 Mode2
+	mode0.Decode(d.regs[MODE0_address])
+	mode1.Decode(d.regs[MODE1_address])
+	mode2.Decode(d.regs[MODE2_address])
+	return SettlingFor(mode0, mode1, mode2)
+}
@@ -0,0 +1,44 @@
+package ads126x
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettlingFor(t *testing.T) {
+	ms := func(v float64) time.Duration { return time.Duration(v * float64(time.Millisecond)) }
+	tests := []struct {
+		name    string
+		mode0   Mode0
+		mode1   Mode1
+		mode2   Mode2
+		first   time.Duration
+		discard int
+		rate    float64
+	}{
+		{"sinc1 at 38400 SPS", Mode0{}, Mode1{Filter: FilterSinc1}, Mode2{Rate: Rate38400}, ms(0.207), 1, 38400},
+		{"sinc3 at 2.5 SPS", Mode0{}, Mode1{Filter: FilterSinc3}, Mode2{Rate: Rate2_5}, ms(1200), 3, 2.5},
+		{"sinc4 at 400 SPS", Mode0{}, Mode1{Filter: FilterSinc4}, Mode2{Rate: Rate400}, ms(10.36), 4, 400},
+		{"sinc2 at 1200 SPS", Mode0{}, Mode1{Filter: FilterSinc2}, Mode2{Rate: Rate1200}, ms(2.022), 2, 1200},
+		{"FIR at 20 SPS", Mode0{}, Mode1{Filter: FilterFIR}, Mode2{Rate: Rate20}, ms(52.22), 1, 20},
+		{"FIR at 2.5 SPS", Mode0{}, Mode1{Filter: FilterFIR}, Mode2{Rate: Rate2_5}, ms(402.2), 1, 2.5},
+		{"conversion delay", Mode0{Delay: Delay8_8ms}, Mode1{Filter: FilterSinc1}, Mode2{Rate: Rate100}, ms(10.35 + 8.8), 1, 100},
+		{"chop", Mode0{Chop: ChopInput}, Mode1{Filter: FilterSinc1}, Mode2{Rate: Rate100}, ms(2 * 10.35), 1, 50},
+		//the FIR filter doesn't work at 400 SPS so the table has no entry and the bound is used
+		{"FIR outside the table", Mode0{}, Mode1{Filter: FilterFIR}, Mode2{Rate: Rate400}, 2*ms(2.5) + latencyAllowance, 1, 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SettlingFor(tt.mode0, tt.mode1, tt.mode2)
+			if diff := got.FirstConversion - tt.first; diff < -time.Microsecond || diff > time.Microsecond {
+				t.Errorf("FirstConversion = %v, want %v", got.FirstConversion, tt.first)
+			}
+			if got.Discard != tt.discard {
+				t.Errorf("Discard = %d, want %d", got.Discard, tt.discard)
+			}
+			if got.Rate < tt.rate*0.999 || got.Rate > tt.rate*1.001 {
+				t.Errorf("Rate = %v, want %v", got.Rate, tt.rate)
+			}
+		})
+	}
+}
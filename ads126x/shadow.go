@@ -0,0 +1,163 @@
+package ads126x
+
+import "fmt"
+
+//The Device keeps a shadow copy of the registers (00h to 1Ah) so single fields can be changed without rebuilding whole register bytes by hand. Sync fills the shadow from the chip in one read. Changes are written in as few WREG transfers as possible - registers in between changed ones are rewritten with their shadow values so the changes go in one burst, as long as the shadow values of those registers are known to match the chip and they aren't MODE or calibration registers. A register whose shadow value isn't known to match the chip is always written, even if the shadow already holds the value asked for.
+
+//resetKnown marks every register known after a reset except the ID register, which differs between chips
+var resetKnown = func() (known [numRegisters]bool) {
+	for reg := range known {
+		known[reg] = reg != int(ID_address)
+	}
+	return known
+}()
+
+//Sync reads all the registers in a single transfer and updates the shadow copy
+func (d *Device) Sync() error {
+	_, err := d.ReadRegisters(ID_address, numRegisters)
+	return err
+}
+
+//Shadow returns the shadow copy of the registers, indexed by address. Registers that were never read, written or reset since the device was created hold their default values.
+func (d *Device) Shadow() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]byte(nil), d.regs[:]...)
+}
+
+//forget marks registers the chip changes by itself, for example with a calibration command, as no longer matching the shadow
+func (d *Device) forget(startingreg byte, n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for reg := int(startingreg); reg < int(startingreg)+n && reg < numRegisters; reg++ {
+		d.known[reg] = false
+	}
+}
+
+//Field is a field of a register. Value is the field value already shifted into place, like the register constants (for example MODE2_DR_400 with MODE2_DR_mask).
+type Field struct {
+	Address byte
+	Mask    byte
+	Value   byte
+}
+
+//SetField changes a single field of a register, leaving the other fields as they are in the shadow copy
+func (d *Device) SetField(address, mask, value byte) error {
+	return d.SetFields(Field{Address: address, Mask: mask, Value: value})
+}
+
+//SetFields changes fields in one or more registers, leaving the other fields as they are in the shadow copy. Only the registers that change are written, grouped into as few WREG transfers as possible. Call Sync first if the registers may have been changed by something other than this Device.
+func (d *Device) SetFields(fields ...Field) error {
+	d.mu.Lock()
+	regs := d.regs
+	d.mu.Unlock()
+	values := make(map[byte]byte)
+	for _, f := range fields {
+		if int(f.Address) >= numRegisters || f.Address == ID_address {
+			return fmt.Errorf("ads126x: register 0x%02X can't be written", f.Address)
+		}
+		if f.Value&^f.Mask != 0 {
+			return fmt.Errorf("ads126x: value 0x%02X is outside the field mask 0x%02X of %s", f.Value, f.Mask, RegisterName(f.Address))
+		}
+		regs[f.Address] = regs[f.Address]&^f.Mask | f.Value
+		values[f.Address] = regs[f.Address]
+	}
+	_, err := d.writeChanged(values)
+	return err
+}
+
+//UpdateConfig decodes the shadow copy into a Config, lets update change it and writes back the registers that changed
+func (d *Device) UpdateConfig(update func(*Config)) error {
+	c, err := d.shadowConfig()
+	if err != nil {
+		return err
+	}
+	update(&c)
+	return d.ApplyConfig(c)
+}
+
+//shadowConfig decodes the shadow copy into a Config
+func (d *Device) shadowConfig() (Config, error) {
+	d.mu.Lock()
+	regs := d.regs
+	d.mu.Unlock()
+	c, vs := configFromRegisters(regs)
+	if errs := vs.Errors(); errs != nil {
+		return Config{}, &ConfigError{Violations: errs}
+	}
+	return c, nil
+}
+
+//writeChanged validates the register values together and writes the ones that differ from the shadow copy, or that aren't known to match the chip. It returns true if anything was written.
+func (d *Device) writeChanged(values map[byte]byte) (bool, error) {
+	if err := d.checkWrite(values); err != nil {
+		return false, err
+	}
+	d.mu.Lock()
+	regs := d.regs
+	known := d.known
+	d.mu.Unlock()
+	changed := false
+	for _, burst := range planBursts(regs, known, values) {
+		if err := d.writeRegisters(burst.start, burst.data); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+//burst is a single WREG transfer
+type burst struct {
+	start byte
+	data  []byte
+}
+
+//noRewrite are the registers that are never written again unchanged just to join bursts. Writing a MODE register restarts conversions, and the chip writes the calibration registers itself, so rewriting them could undo a calibration that finished since the shadow was last read.
+var noRewrite = func() (regs [numRegisters]bool) {
+	for _, reg := range []byte{MODE0_address, MODE1_address, MODE2_address,
+		OFCAL0_address, OFCAL1_address, OFCAL2_address, FSCAL0_address, FSCAL1_address, FSCAL2_address,
+		ADC2OFC0_address, ADC2OFC1_address, ADC2FSC0_address, ADC2FSC1_address} {
+		regs[reg] = true
+	}
+	return regs
+}()
+
+//planBursts groups the registers that change into as few WREG transfers as possible. Changed registers are joined into one burst across the registers between them if those registers have known shadow values, which are written again unchanged. Bursts are never joined across the MODE or calibration registers (see noRewrite).
+func planBursts(regs [numRegisters]byte, known [numRegisters]bool, values map[byte]byte) []burst {
+	var bursts []burst
+	//current is the index of the burst being built, or -1 if there is none
+	current := -1
+	//gap holds the unchanged registers since the last change of the current burst
+	var gap []byte
+	for reg := 0; reg < numRegisters; reg++ {
+		value, ok := values[byte(reg)]
+		//a register not known to hold the value is written even if the shadow already has it
+		if ok && (value != regs[reg] || !known[reg]) {
+			if current < 0 {
+				bursts = append(bursts, burst{start: byte(reg)})
+				current = len(bursts) - 1
+			}
+			bursts[current].data = append(append(bursts[current].data, gap...), value)
+			gap = nil
+			continue
+		}
+		if current < 0 {
+			continue
+		}
+		switch {
+		case noRewrite[reg]:
+			current = -1
+			gap = nil
+		case ok:
+			//a value that is written anyway is known
+			gap = append(gap, value)
+		case known[reg]:
+			gap = append(gap, regs[reg])
+		default:
+			current = -1
+			gap = nil
+		}
+	}
+	return bursts
+}
@@ -0,0 +1,116 @@
+package ads126x
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanBursts(t *testing.T) {
+	//unknown is resetKnown with the given registers marked as not matching the chip
+	unknown := func(regs ...byte) [numRegisters]bool {
+		known := resetKnown
+		for _, reg := range regs {
+			known[reg] = false
+		}
+		return known
+	}
+	tests := []struct {
+		name   string
+		known  [numRegisters]bool
+		values map[byte]byte
+		want   []burst
+	}{
+		{
+			name:   "nothing changed",
+			known:  resetKnown,
+			values: map[byte]byte{MODE2_address: MODE2_default, REFMUX_address: REFMUX_default},
+			want:   nil,
+		},
+		{
+			name:   "one register",
+			known:  resetKnown,
+			values: map[byte]byte{INPMUX_address: 0x23},
+			want:   []burst{{INPMUX_address, []byte{0x23}}},
+		},
+		{
+			name:   "adjacent registers",
+			known:  resetKnown,
+			values: map[byte]byte{IDACMUX_address: 0x00, IDACMAG_address: 0x11},
+			want:   []burst{{IDACMUX_address, []byte{0x00, 0x11}}},
+		},
+		{
+			name:   "gap of known registers is rewritten",
+			known:  resetKnown,
+			values: map[byte]byte{IDACMUX_address: 0x00, REFMUX_address: 0x12},
+			want:   []burst{{IDACMUX_address, []byte{0x00, IDACMAG_default, 0x12}}},
+		},
+		{
+			name:   "unchanged value in the gap",
+			known:  resetKnown,
+			values: map[byte]byte{IDACMUX_address: 0x00, IDACMAG_address: IDACMAG_default, REFMUX_address: 0x12},
+			want:   []burst{{IDACMUX_address, []byte{0x00, IDACMAG_default, 0x12}}},
+		},
+		{
+			name:   "unknown register is written even if unchanged",
+			known:  unknown(IDACMAG_address),
+			values: map[byte]byte{IDACMAG_address: IDACMAG_default},
+			want:   []burst{{IDACMAG_address, []byte{IDACMAG_default}}},
+		},
+		{
+			name:   "unknown register in the value gap is written",
+			known:  unknown(IDACMAG_address),
+			values: map[byte]byte{IDACMUX_address: 0x00, IDACMAG_address: IDACMAG_default, REFMUX_address: 0x12},
+			want:   []burst{{IDACMUX_address, []byte{0x00, IDACMAG_default, 0x12}}},
+		},
+		{
+			name:   "no bridging over an unknown register",
+			known:  unknown(IDACMAG_address),
+			values: map[byte]byte{IDACMUX_address: 0x00, REFMUX_address: 0x12},
+			want:   []burst{{IDACMUX_address, []byte{0x00}}, {REFMUX_address, []byte{0x12}}},
+		},
+		{
+			name:   "no bridging over the MODE registers",
+			known:  resetKnown,
+			values: map[byte]byte{INTERFACE_address: 0x06, INPMUX_address: 0x23},
+			want:   []burst{{INTERFACE_address, []byte{0x06}}, {INPMUX_address, []byte{0x23}}},
+		},
+		{
+			name:   "no bridging over an unchanged MODE register",
+			known:  resetKnown,
+			values: map[byte]byte{MODE0_address: 0x40, MODE1_address: MODE1_default, MODE2_address: 0x58},
+			want:   []burst{{MODE0_address, []byte{0x40}}, {MODE2_address, []byte{0x58}}},
+		},
+		{
+			name:   "changed MODE registers are joined",
+			known:  resetKnown,
+			values: map[byte]byte{MODE0_address: 0x40, MODE1_address: 0x60, MODE2_address: 0x58},
+			want:   []burst{{MODE0_address, []byte{0x40, 0x60, 0x58}}},
+		},
+		{
+			name:   "no bridging over the ADC1 calibration registers",
+			known:  resetKnown,
+			values: map[byte]byte{INPMUX_address: 0x23, IDACMUX_address: 0x00},
+			want:   []burst{{INPMUX_address, []byte{0x23}}, {IDACMUX_address, []byte{0x00}}},
+		},
+		{
+			name:   "no bridging over the ADC2 calibration registers",
+			known:  resetKnown,
+			values: map[byte]byte{ADC2MUX_address: 0x23, ADC2FSC1_address: 0x41},
+			want:   []burst{{ADC2MUX_address, []byte{0x23}}, {ADC2FSC1_address, []byte{0x41}}},
+		},
+		{
+			name:   "separate runs",
+			known:  resetKnown,
+			values: map[byte]byte{POWER_address: 0x01, INTERFACE_address: 0x06, GPIOCON_address: 0x01, GPIODAT_address: 0x01},
+			want:   []burst{{POWER_address, []byte{0x01, 0x06}}, {GPIOCON_address, []byte{0x01, GPIODIR_default, 0x01}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planBursts(registerDefaults, tt.known, tt.values)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planBursts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package ads126x
+
+import "strings"
+
+//Status is the status byte sent with the conversion data when it is enabled in the INTERFACE register
+type Status byte
+
+//ADC1NewData is true if the ADC1 data is new since the last ADC1 read
+func (s Status) ADC1NewData() bool { return byte(s)&STATUS_ADC1 != 0 }
+
+//ADC2NewData is true if the ADC2 data is new since the last ADC2 read (ADS1263 only)
+func (s Status) ADC2NewData() bool { return byte(s)&STATUS_ADC2 != 0 }
+
+//ExternalClock is true if the ADC is running from an external clock
+func (s Status) ExternalClock() bool { return byte(s)&STATUS_EXTCLK != 0 }
+
+//ReferenceLow is true if the ADC1 reference voltage is below the alarm threshold (0.4 V)
+func (s Status) ReferenceLow() bool { return byte(s)&STATUS_REF_ALM != 0 }
+
+//PGALow is true if the ADC1 PGA output is below its low limit
+func (s Status) PGALow() bool { return byte(s)&STATUS_PGAL_ALM != 0 }
+
+//PGAHigh is true if the ADC1 PGA output is above its high limit
+func (s Status) PGAHigh() bool { return byte(s)&STATUS_PGAH_ALM != 0 }
+
+//PGADifferential is true if the ADC1 PGA differential output is out of range
+func (s Status) PGADifferential() bool { return byte(s)&STATUS_PGAD_ALM != 0 }
+
+//Reset is true if the ADC was reset since the reset bit in the POWER register was last cleared
+func (s Status) Reset() bool { return byte(s)&STATUS_RESET != 0 }
+
+//Alarm is true if any of the PGA or reference alarms is set, meaning the conversion data may be overranged or taken with too low a reference
+func (s Status) Alarm() bool {
+	return byte(s)&(STATUS_REF_ALM|STATUS_PGAL_ALM|STATUS_PGAH_ALM|STATUS_PGAD_ALM) != 0
+}
+
+func (s Status) String() string {
+	var flags []string
+	for _, f := range []struct {
+		bit  byte
+		name string
+	}{
+		{STATUS_ADC2, "ADC2"}, {STATUS_ADC1, "ADC1"}, {STATUS_EXTCLK, "EXTCLK"}, {STATUS_REF_ALM, "REF_ALM"},
+		{STATUS_PGAL_ALM, "PGAL_ALM"}, {STATUS_PGAH_ALM, "PGAH_ALM"}, {STATUS_PGAD_ALM, "PGAD_ALM"}, {STATUS_RESET, "RESET"},
+	} {
+		if byte(s)&f.bit != 0 {
+			flags = append(flags, f.name)
+		}
+	}
+	if flags == nil {
+		return "none"
+	}
+	return strings.Join(flags, "|")
+}
+
+//Sample is a single conversion result together with the status byte that was read with it
+type Sample struct {
+	//Raw is the unconverted conversion data. ADC2 data is 24 bits, sign extended.
+	Raw    int32
+	Status Status
+	//HasStatus is false if the status byte is disabled in the INTERFACE register. Status is then zero and says nothing about the sample.
+	HasStatus bool
+	//Clipping says if the input was beyond full scale. For ADC1 the PGA alarms in the status byte count as well.
+	Clipping Clipping
+}
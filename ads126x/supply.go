@@ -0,0 +1,201 @@
+package ads126x
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//INPMUX and ADC2MUX can select the analog supply (AVDD – AVSS) and the digital supply (DVDD – DGND) divided by four, so both can be measured against the internal reference with a gain of one. A logger running from a battery or a regulator can use them to catch brownouts, which corrupt conversions long before the chip resets.
+
+//supplyDivider is the ratio the supply monitor inputs divide the supplies by
+const supplyDivider = 4
+
+//ReadSupply measures a supply in volts with ADC1. supply is AnalogSupply for AVDD – AVSS or DigitalSupply for DVDD – DGND. Like ReadTemperature it averages the given number of settled conversions, writes back the previous settings and leaves ADC1 conversions stopped.
+func (d *Device) ReadSupply(supply Input, samples int) (float64, error) {
+	if err := checkSupply(supply); err != nil {
+		return 0, err
+	}
+	volts, err := d.readMonitor(supply, samples)
+	return volts * supplyDivider, err
+}
+
+//ReadSupplyADC2 is like ReadSupply but measures with ADC2 of the ADS1263, leaving ADC1 alone. The status byte must be enabled.
+func (d *Device) ReadSupplyADC2(supply Input, samples int) (float64, error) {
+	if err := checkSupply(supply); err != nil {
+		return 0, err
+	}
+	volts, err := d.readMonitorADC2(supply, samples)
+	return volts * supplyDivider, err
+}
+
+//checkSupply returns an error if the input isn't a supply monitor
+func checkSupply(supply Input) error {
+	if supply != AnalogSupply && supply != DigitalSupply {
+		return fmt.Errorf("ads126x: %v is not a supply monitor, use AnalogSupply or DigitalSupply", supply)
+	}
+	return nil
+}
+
+//SupplyState is whether a supply is within its limits
+type SupplyState byte
+
+const (
+	SupplyNormal SupplyState = iota
+	SupplyUndervoltage
+	SupplyOvervoltage
+)
+
+var supplyStateNames = [...]string{"normal", "under-voltage", "over-voltage"}
+
+func (s SupplyState) String() string {
+	if int(s) >= len(supplyStateNames) {
+		return fmt.Sprintf("SupplyState(%d)", byte(s))
+	}
+	return supplyStateNames[s]
+}
+
+//SupplyLimits are the thresholds of one supply in volts. A zero threshold is not checked.
+type SupplyLimits struct {
+	Under float64
+	Over  float64
+	//Hysteresis is how far back inside a threshold the supply has to come before it is normal again, so a supply sitting on a threshold doesn't raise an event on every check
+	Hysteresis float64
+}
+
+//set is true if either threshold is set
+func (l SupplyLimits) set() bool {
+	return l.Under != 0 || l.Over != 0
+}
+
+//check returns an error if the limits can't work
+func (l SupplyLimits) check(supply Input) error {
+	switch {
+	case l.Under < 0 || l.Over < 0 || l.Hysteresis < 0:
+		return fmt.Errorf("ads126x: %v limits %+v must not be negative", supply, l)
+	case l.Under != 0 && l.Over != 0 && l.Under+l.Hysteresis >= l.Over-l.Hysteresis:
+		return fmt.Errorf("ads126x: %v under-voltage threshold %v V must be below the over-voltage threshold %v V by more than twice the hysteresis", supply, l.Under, l.Over)
+	}
+	return nil
+}
+
+//state is the state of a supply at volts that was in state current before
+func (l SupplyLimits) state(volts float64, current SupplyState) SupplyState {
+	switch {
+	case l.Under != 0 && volts < l.Under:
+		return SupplyUndervoltage
+	case l.Over != 0 && volts > l.Over:
+		return SupplyOvervoltage
+	case current == SupplyUndervoltage && volts < l.Under+l.Hysteresis:
+		return SupplyUndervoltage
+	case current == SupplyOvervoltage && volts > l.Over-l.Hysteresis:
+		return SupplyOvervoltage
+	}
+	return SupplyNormal
+}
+
+//SupplyThresholds sets what a SupplyMonitor checks. Supplies without thresholds are not measured.
+type SupplyThresholds struct {
+	//Analog is the limits of AVDD – AVSS
+	Analog SupplyLimits
+	//Digital is the limits of DVDD – DGND
+	Digital SupplyLimits
+	//Samples is the number of conversions averaged for each reading. Zero means 1.
+	Samples int
+	//ADC2 measures the supplies with ADC2 of the ADS1263 so ADC1 can keep converting in between checks
+	ADC2 bool
+}
+
+//SupplyEvent is raised when a supply goes outside its limits or comes back inside them
+type SupplyEvent struct {
+	//Supply is AnalogSupply or DigitalSupply
+	Supply   Input
+	State    SupplyState
+	Previous SupplyState
+	Volts    float64
+	Time     time.Time
+}
+
+func (e SupplyEvent) String() string {
+	return fmt.Sprintf("%v %.3f V %v (was %v)", e.Supply, e.Volts, e.State, e.Previous)
+}
+
+//SupplyMonitor measures the supplies and raises a SupplyEvent each time one crosses a threshold. Use NewSupplyMonitor to create one.
+type SupplyMonitor struct {
+	dev    *Device
+	config SupplyThresholds
+	states map[Input]SupplyState
+}
+
+//NewSupplyMonitor creates a SupplyMonitor for the device. Both supplies start out normal, so a supply that is already outside its limits raises an event on the first check.
+func NewSupplyMonitor(dev *Device, config SupplyThresholds) (*SupplyMonitor, error) {
+	if err := config.Analog.check(AnalogSupply); err != nil {
+		return nil, err
+	}
+	if err := config.Digital.check(DigitalSupply); err != nil {
+		return nil, err
+	}
+	if !config.Analog.set() && !config.Digital.set() {
+		return nil, errors.New("ads126x: the supply monitor has no thresholds to check")
+	}
+	return &SupplyMonitor{dev: dev, config: config, states: map[Input]SupplyState{AnalogSupply: SupplyNormal, DigitalSupply: SupplyNormal}}, nil
+}
+
+//State is the state of a supply as of the last check
+func (m *SupplyMonitor) State(supply Input) SupplyState {
+	return m.states[supply]
+}
+
+//Check measures the supplies that have thresholds and returns an event for each one that changed state since the last check. With ADC1 (the default) conversions are stopped afterwards, see ReadSupply.
+func (m *SupplyMonitor) Check() ([]SupplyEvent, error) {
+	var events []SupplyEvent
+	for _, supply := range []Input{AnalogSupply, DigitalSupply} {
+		limits := m.config.Analog
+		if supply == DigitalSupply {
+			limits = m.config.Digital
+		}
+		if !limits.set() {
+			continue
+		}
+		read := m.dev.ReadSupply
+		if m.config.ADC2 {
+			read = m.dev.ReadSupplyADC2
+		}
+		volts, err := read(supply, m.config.Samples)
+		if err != nil {
+			return events, err
+		}
+		previous := m.states[supply]
+		state := limits.state(volts, previous)
+		if state != previous {
+			m.states[supply] = state
+			events = append(events, SupplyEvent{Supply: supply, State: state, Previous: previous, Volts: volts, Time: time.Now()})
+		}
+	}
+	return events, nil
+}
+
+//Run checks the supplies every interval and sends the events to events until ctx is done, in which case it returns the context's error. Checks that fail the checksum are skipped. Any other error ends the run and is returned.
+func (m *SupplyMonitor) Run(ctx context.Context, interval time.Duration, events chan<- SupplyEvent) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		found, err := m.Check()
+		if err != nil && !errors.Is(err, ErrChecksum) {
+			return err
+		}
+		for _, event := range found {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package ads126x
+
+import "testing"
+
+func TestSupplyLimitsState(t *testing.T) {
+	limits := SupplyLimits{Under: 4.75, Over: 5.25, Hysteresis: 0.05}
+	//each step is a reading and the state it should lead to, starting from normal
+	tests := []struct {
+		name  string
+		steps []float64
+		want  []SupplyState
+	}{
+		{"normal", []float64{5, 4.8, 5.2}, []SupplyState{SupplyNormal, SupplyNormal, SupplyNormal}},
+		{"normal to low and back", []float64{4.7, 4.9}, []SupplyState{SupplyUndervoltage, SupplyNormal}},
+		{"low stays low in the hysteresis band", []float64{4.7, 4.76, 4.79, 4.8}, []SupplyState{SupplyUndervoltage, SupplyUndervoltage, SupplyUndervoltage, SupplyNormal}},
+		{"normal in the hysteresis band is still normal", []float64{4.76, 4.7, 4.77}, []SupplyState{SupplyNormal, SupplyUndervoltage, SupplyUndervoltage}},
+		{"high stays high in the hysteresis band", []float64{5.3, 5.21, 5.2}, []SupplyState{SupplyOvervoltage, SupplyOvervoltage, SupplyNormal}},
+		{"low to high", []float64{4.5, 5.5}, []SupplyState{SupplyUndervoltage, SupplyOvervoltage}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := SupplyNormal
+			for i, volts := range tt.steps {
+				state = limits.state(volts, state)
+				if state != tt.want[i] {
+					t.Errorf("step %d at %v V: state %v, want %v", i, volts, state, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSupplyLimitsOneSided(t *testing.T) {
+	under := SupplyLimits{Under: 3.0, Hysteresis: 0.1}
+	if got := under.state(10, SupplyNormal); got != SupplyNormal {
+		t.Errorf("no over-voltage threshold: state %v at 10 V, want normal", got)
+	}
+	over := SupplyLimits{Over: 3.6}
+	if got := over.state(0, SupplyNormal); got != SupplyNormal {
+		t.Errorf("no under-voltage threshold: state %v at 0 V, want normal", got)
+	}
+}
+
+func TestSupplyLimitsCheck(t *testing.T) {
+	tests := []struct {
+		limits  SupplyLimits
+		wantErr bool
+	}{
+		{SupplyLimits{}, false},
+		{SupplyLimits{Under: 4.75, Over: 5.25, Hysteresis: 0.05}, false},
+		{SupplyLimits{Under: -1}, true},
+		{SupplyLimits{Under: 5, Over: 4}, true},
+		//the hysteresis bands would overlap
+		{SupplyLimits{Under: 4.9, Over: 5.1, Hysteresis: 0.1}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.limits.check(AnalogSupply); (err != nil) != tt.wantErr {
+			t.Errorf("%+v: check() error = %v, want error %v", tt.limits, err, tt.wantErr)
+		}
+	}
+}
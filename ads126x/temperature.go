@@ -0,0 +1,183 @@
+package ads126x
+
+import "time"
+
+//The temperature sensor is a pair of diodes on the die (see section 9.3.4 of the datasheet). INPMUX or ADC2MUX select it like any other input. Measured with a gain of one against the internal reference it reads 122.4 mV at 25 °C and changes by 420 µV/°C. The die runs a little warmer than the board because of self heating, which matters when it is used for cold junction compensation.
+
+//Datasheet constants of the temperature sensor
+const (
+	//TempSensorVolts25 is the sensor voltage at 25 °C
+	TempSensorVolts25 = 0.1224
+	//TempSensorSlope is the change of the sensor voltage in V/°C
+	TempSensorSlope = 420e-6
+)
+
+//TemperatureCalibration corrects the temperature sensor of a particular chip, for example after comparing it with a reference thermometer. The zero value uses the datasheet constants unchanged.
+type TemperatureCalibration struct {
+	//Volts25 is the sensor voltage at 25 °C. Zero means TempSensorVolts25.
+	Volts25 float64
+	//Slope is the sensor slope in V/°C. Zero means TempSensorSlope.
+	Slope float64
+	//Offset is added to the temperature in °C, for example -0.7 to take off self heating
+	Offset float64
+}
+
+//Celsius converts a temperature sensor voltage into °C
+func (c TemperatureCalibration) Celsius(volts float64) float64 {
+	volts25, slope := c.Volts25, c.Slope
+	if volts25 == 0 {
+		volts25 = TempSensorVolts25
+	}
+	if slope == 0 {
+		slope = TempSensorSlope
+	}
+	return (volts-volts25)/slope + 25 + c.Offset
+}
+
+//monitorMode2 is the gain and data rate the temperature sensor and supply monitors are read with on ADC1
+var monitorMode2 = Mode2{Gain: Gain1, Rate: Rate20}
+
+//monitorADC2 is the ADC2CFG setting the temperature sensor and supply monitors are read with on ADC2
+var monitorADC2 = ADC2Config{Rate: ADC2Rate100, Ref: ADC2RefInternal, Gain: ADC2Gain1}
+
+//adc2Discard is the number of ADC2 conversions thrown away after the inputs change. ADC2 has a sinc3 filter.
+const adc2Discard = 3
+
+//refSettle is how long to wait after switching on the internal reference before measuring against it
+var refSettle = 100 * time.Millisecond
+
+//SetTemperatureCalibration sets the calibration ReadTemperature and ReadTemperatureADC2 use
+func (d *Device) SetTemperatureCalibration(c TemperatureCalibration) {
+	d.mu.Lock()
+	d.tempcal = c
+	d.mu.Unlock()
+}
+
+//TemperatureCalibration is the calibration ReadTemperature and ReadTemperatureADC2 use
+func (d *Device) TemperatureCalibration() TemperatureCalibration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.tempcal
+}
+
+//ReadTemperature measures the die temperature in °C with ADC1. It switches INPMUX to the temperature sensor with a gain of one and the internal reference, averages the given number of single, settled conversions in pulse mode and then writes back the previous settings. ADC1 conversions are stopped afterwards.
+func (d *Device) ReadTemperature(samples int) (float64, error) {
+	volts, err := d.readMonitor(TempSensor, samples)
+	if err != nil {
+		return 0, err
+	}
+	return d.TemperatureCalibration().Celsius(volts), nil
+}
+
+//ReadTemperatureADC2 measures the die temperature in °C with ADC2 of the ADS1263, leaving ADC1 alone. It switches ADC2MUX to the temperature sensor with a gain of one and the internal reference, throws away the conversions the ADC2 filter needs to settle, averages the given number of conversions and then writes back the previous settings. ADC2 conversions are stopped afterwards. The status byte must be enabled since it is polled for new data.
+func (d *Device) ReadTemperatureADC2(samples int) (float64, error) {
+	volts, err := d.readMonitorADC2(TempSensor, samples)
+	if err != nil {
+		return 0, err
+	}
+	return d.TemperatureCalibration().Celsius(volts), nil
+}
+
+//readMonitor reads one of the internal monitor inputs - the temperature sensor or a supply monitor - with ADC1 as described for ReadTemperature and returns the average voltage
+func (d *Device) readMonitor(monitor Input, samples int) (float64, error) {
+	if samples < 1 {
+		samples = 1
+	}
+	if err := d.Stop(); err != nil {
+		return 0, err
+	}
+	previous, err := d.shadowConfig()
+	if err != nil {
+		return 0, err
+	}
+	err = d.UpdateConfig(func(c *Config) {
+		c.Power.InternalRef = true
+		c.Mode0.RunMode = RunPulse
+		c.Mode2 = monitorMode2
+		c.InpMux = InpMux{Positive: monitor, Negative: monitor}
+		c.RefMux = RefMux{}
+	})
+	if err == nil && !previous.Power.InternalRef {
+		time.Sleep(refSettle)
+	}
+	scale := ADC1Scale(InternalReference, monitorMode2)
+	var sum float64
+	for i := 0; err == nil && i < samples; i++ {
+		var sample Sample
+		if sample, err = d.ReadOnce(d.Timeout()); err == nil {
+			sum += scale.Volts(sample.Raw)
+		}
+	}
+	if restoreerr := d.ApplyConfig(previous); err == nil {
+		err = restoreerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return sum / float64(samples), nil
+}
+
+//readMonitorADC2 reads one of the internal monitor inputs with ADC2 as described for ReadTemperatureADC2 and returns the average voltage
+func (d *Device) readMonitorADC2(monitor Input, samples int) (float64, error) {
+	if samples < 1 {
+		samples = 1
+	}
+	if err := d.needStatus(); err != nil {
+		return 0, err
+	}
+	if err := d.StopADC2(); err != nil {
+		return 0, err
+	}
+	previous, err := d.shadowConfig()
+	if err != nil {
+		return 0, err
+	}
+	err = d.UpdateConfig(func(c *Config) {
+		c.Power.InternalRef = true
+		c.ADC2 = monitorADC2
+		c.ADC2Mux = ADC2Mux{Positive: monitor, Negative: monitor}
+	})
+	if err == nil && !previous.Power.InternalRef {
+		time.Sleep(refSettle)
+	}
+	if err == nil {
+		err = d.StartADC2()
+	}
+	scale := ADC2Scale(InternalReference, monitorADC2)
+	period := time.Duration(float64(time.Second) / monitorADC2.Rate.SPS())
+	var sum float64
+	for i := -adc2Discard; err == nil && i < samples; i++ {
+		var sample Sample
+		if sample, err = d.readNextADC2(period + timeoutMargin); err == nil && i >= 0 {
+			sum += scale.Volts(sample.Raw)
+		}
+	}
+	if stoperr := d.StopADC2(); err == nil {
+		err = stoperr
+	}
+	if restoreerr := d.ApplyConfig(previous); err == nil {
+		err = restoreerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return sum / float64(samples), nil
+}
+
+//readNextADC2 polls RDATA2 until the status byte shows new ADC2 data. It gives up with a TimeoutError after timeout.
+func (d *Device) readNextADC2(timeout time.Duration) (Sample, error) {
+	d.mu.Lock()
+	interval := d.pollinterval
+	d.mu.Unlock()
+	start := time.Now()
+	for {
+		sample, err := d.readADC2Sample()
+		if err != nil || sample.Status.ADC2NewData() {
+			return sample, err
+		}
+		if time.Since(start) > timeout {
+			return Sample{}, &TimeoutError{Waited: time.Since(start)}
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,282 @@
+package ads126x
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//Some combinations of settings are accepted by the registers but don't work, for example the FIR filter at a data rate it isn't designed for. The validator checks a complete set of register settings against the limits in the datasheet. Errors are combinations that can't work and warnings are ones that work but are most likely a mistake. Writes through a Device are validated before they are sent and are refused if they would cause an error (see SetValidation).
+
+//Severity says how bad a violation is
+type Severity byte
+
+const (
+	//SeverityWarning means the settings work but are most likely a mistake
+	SeverityWarning Severity = iota
+	//SeverityError means the settings can't work
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+//Violation is a single problem found by the validator
+type Violation struct {
+	Severity Severity
+	//Registers are the addresses of the registers involved
+	Registers []byte
+	Message   string
+}
+
+func (v Violation) String() string {
+	return v.Severity.String() + ": " + v.Message
+}
+
+//involves is true if any of the registers involved is in the given range
+func (v Violation) involves(startingreg byte, n int) bool {
+	for _, reg := range v.Registers {
+		if reg >= startingreg && int(reg) < int(startingreg)+n {
+			return true
+		}
+	}
+	return false
+}
+
+//Violations is the list of problems found by the validator
+type Violations []Violation
+
+//Errors returns only the violations with SeverityError
+func (vs Violations) Errors() Violations {
+	var errs Violations
+	for _, v := range vs {
+		if v.Severity == SeverityError {
+			errs = append(errs, v)
+		}
+	}
+	return errs
+}
+
+//Warnings returns only the violations with SeverityWarning
+func (vs Violations) Warnings() Violations {
+	var warnings Violations
+	for _, v := range vs {
+		if v.Severity == SeverityWarning {
+			warnings = append(warnings, v)
+		}
+	}
+	return warnings
+}
+
+func (vs Violations) String() string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = v.String()
+	}
+	return strings.Join(s, "; ")
+}
+
+//ConfigError is returned when a register write is refused because the settings would be invalid
+type ConfigError struct {
+	Violations Violations
+}
+
+func (e *ConfigError) Error() string {
+	return "ads126x: invalid configuration: " + e.Violations.String()
+}
+
+func (e *ConfigError) Is(target error) bool { return target == ErrInvalidConfig }
+
+//Config is a complete set of ADC settings. It can be validated, applied to a Device, or loaded from a JSON file with LoadConfig.
+type Config struct {
+	Power     PowerConfig
+	Interface InterfaceConfig
+	Mode0     Mode0
+	Mode1     Mode1
+	Mode2     Mode2
+	InpMux    InpMux
+	IDACMux   IDACMux
+	IDACMag   IDACMag
+	RefMux    RefMux
+	ADC2      ADC2Config
+	ADC2Mux   ADC2Mux
+}
+
+//registerConfigs lists the register settings of the config
+func (c Config) registerConfigs() []RegisterConfig {
+	return []RegisterConfig{c.Power, c.Interface, c.Mode0, c.Mode1, c.Mode2, c.InpMux, c.IDACMux, c.IDACMag, c.RefMux, c.ADC2, c.ADC2Mux}
+}
+
+//registers encodes the config
+func (c Config) registers() (map[byte]byte, error) {
+	values := make(map[byte]byte)
+	for _, config := range c.registerConfigs() {
+		value, err := config.Encode()
+		if err != nil {
+			return nil, err
+		}
+		values[config.Address()] = value
+	}
+	return values, nil
+}
+
+//configFromRegisters decodes the settings in a set of register values. Values that can't be decoded are returned as violations.
+func configFromRegisters(regs [numRegisters]byte) (Config, Violations) {
+	var c Config
+	var vs Violations
+	for _, r := range []RegisterReader{&c.Power, &c.Interface, &c.Mode0, &c.Mode1, &c.Mode2, &c.InpMux, &c.IDACMux, &c.IDACMag, &c.RefMux, &c.ADC2, &c.ADC2Mux} {
+		if err := r.Decode(regs[r.Address()]); err != nil {
+			vs = append(vs, Violation{SeverityError, []byte{r.Address()}, err.Error()})
+		}
+	}
+	return c, vs
+}
+
+//DefaultConfig is the config of the ADC after a reset
+func DefaultConfig() Config {
+	c, _ := configFromRegisters(registerDefaults)
+	return c
+}
+
+//firRates are the data rates the FIR filter works at
+var firRates = map[DataRate]bool{Rate2_5: true, Rate5: true, Rate10: true, Rate20: true}
+
+//Validate checks the config against the limits in the datasheet
+func (c Config) Validate() Violations {
+	var vs Violations
+	add := func(severity Severity, message string, registers ...byte) {
+		vs = append(vs, Violation{severity, registers, message})
+	}
+	if c.Mode1.Filter == FilterFIR && !firRates[c.Mode2.Rate] {
+		add(SeverityError, fmt.Sprintf("the FIR filter only works at 2.5, 5, 10 and 20 SPS, not %v SPS", c.Mode2.Rate.SPS()), MODE1_address, MODE2_address)
+	}
+	if c.Mode2.Bypass && c.Mode2.Gain != Gain1 {
+		add(SeverityError, fmt.Sprintf("the PGA is bypassed so the gain must be 1, not %d", c.Mode2.Gain.Factor()), MODE2_address)
+	}
+	idacs := c.IDACMag.IDAC1 != IDACOff || c.IDACMag.IDAC2 != IDACOff
+	if idacs && !c.Power.InternalRef {
+		add(SeverityError, "the IDACs are on but they need the internal reference, which is off", POWER_address, IDACMAG_address)
+	}
+	if (c.RefMux.Positive == RefPInternal || c.RefMux.Negative == RefNInternal) && !c.Power.InternalRef {
+		add(SeverityError, "REFMUX selects the internal reference, which is off", POWER_address, REFMUX_address)
+	}
+	if c.InpMux.Positive == c.InpMux.Negative && c.InpMux.Positive <= AINCOM {
+		add(SeverityWarning, fmt.Sprintf("%v is both the positive and the negative ADC1 input", c.InpMux.Positive), INPMUX_address)
+	}
+	if c.ADC2Mux.Positive == c.ADC2Mux.Negative && c.ADC2Mux.Positive <= AINCOM {
+		add(SeverityWarning, fmt.Sprintf("%v is both the positive and the negative ADC2 input", c.ADC2Mux.Positive), ADC2MUX_address)
+	}
+	if c.IDACMag.IDAC1 != IDACOff && c.IDACMag.IDAC2 != IDACOff && c.IDACMux.IDAC1 == c.IDACMux.IDAC2 && c.IDACMux.IDAC1 <= AINCOM {
+		add(SeverityWarning, fmt.Sprintf("both IDACs drive %v, so their currents add up", c.IDACMux.IDAC1), IDACMUX_address, IDACMAG_address)
+	}
+	if c.IDACMag.IDAC1 != IDACOff && c.IDACMux.IDAC1 > AINCOM {
+		add(SeverityWarning, "IDAC1 is on but not connected to a pin", IDACMUX_address, IDACMAG_address)
+	}
+	if c.IDACMag.IDAC2 != IDACOff && c.IDACMux.IDAC2 > AINCOM {
+		add(SeverityWarning, "IDAC2 is on but not connected to a pin", IDACMUX_address, IDACMAG_address)
+	}
+	if (c.Mode0.Chop == ChopIDACRotation || c.Mode0.Chop == ChopInputAndIDACRotation) && !idacs {
+		add(SeverityWarning, "IDAC rotation is on but both IDACs are off", MODE0_address, IDACMAG_address)
+	}
+	return vs
+}
+
+//validateRegisters decodes and validates a set of register values
+func validateRegisters(regs [numRegisters]byte) Violations {
+	c, vs := configFromRegisters(regs)
+	return append(vs, c.Validate()...)
+}
+
+//LoadConfig reads a config from a JSON file. Settings missing from the file keep their default values (see DefaultConfig).
+func LoadConfig(path string) (Config, error) {
+	c := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("ads126x: reading config from %s: %w", path, err)
+	}
+	return c, nil
+}
+
+//ValidateFile loads a config from a JSON file and validates it, without needing an ADC
+func ValidateFile(path string) (Violations, error) {
+	c, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.registers(); err != nil {
+		return Violations{{SeverityError, nil, err.Error()}}, nil
+	}
+	return c.Validate(), nil
+}
+
+//Validation sets whether register writes are validated
+type Validation byte
+
+const (
+	//ValidateErrors refuses writes that would leave the registers with errors. Warnings are ignored. This is the default.
+	ValidateErrors Validation = iota
+	//ValidateOff writes anything
+	ValidateOff
+)
+
+//SetValidation sets whether register writes are validated
+func (d *Device) SetValidation(validation Validation) {
+	d.mu.Lock()
+	d.validation = validation
+	d.mu.Unlock()
+}
+
+//Validate validates the settings the device has been configured with
+func (d *Device) Validate() Violations {
+	d.mu.Lock()
+	regs := d.regs
+	d.mu.Unlock()
+	return validateRegisters(regs)
+}
+
+//checkWrite validates the registers as they would be after the given values are written. Errors involving registers that are not written are left alone, so a write is never blocked by a problem it doesn't touch.
+func (d *Device) checkWrite(values map[byte]byte) error {
+	d.mu.Lock()
+	regs := d.regs
+	validation := d.validation
+	d.mu.Unlock()
+	if validation == ValidateOff {
+		return nil
+	}
+	for reg, value := range values {
+		if int(reg) < numRegisters {
+			regs[reg] = value
+		}
+	}
+	var errs Violations
+	for _, v := range validateRegisters(regs).Errors() {
+		for reg := range values {
+			if v.involves(reg, 1) {
+				errs = append(errs, v)
+				break
+			}
+		}
+	}
+	if errs != nil {
+		return &ConfigError{Violations: errs}
+	}
+	return nil
+}
+
+//ApplyConfig validates the config and writes the registers that differ from the device's current settings
+func (d *Device) ApplyConfig(c Config) error {
+	values, err := c.registers()
+	if err != nil {
+		return err
+	}
+	_, err = d.writeChanged(values)
+	return err
+}
@@ -0,0 +1,191 @@
+package ads126x
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		//want are the severities and registers of the violations, in the order Validate finds them
+		want []Violation
+	}{
+		{"default", func(c *Config) {}, nil},
+		{
+			"internal REFMUX with the reference off",
+			func(c *Config) { c.Power.InternalRef = false },
+			[]Violation{{Severity: SeverityError, Registers: []byte{POWER_address, REFMUX_address}}},
+		},
+		{
+			"external reference with the internal reference off",
+			func(c *Config) {
+				c.Power.InternalRef = false
+				c.RefMux = RefMux{Positive: RefPAIN2, Negative: RefNAIN3}
+			},
+			nil,
+		},
+		{
+			"IDAC without the internal reference",
+			func(c *Config) {
+				c.Power.InternalRef = false
+				c.RefMux = RefMux{Positive: RefPAIN2, Negative: RefNAIN3}
+				c.IDACMux.IDAC1 = AIN4
+				c.IDACMag.IDAC1 = IDAC500uA
+			},
+			[]Violation{{Severity: SeverityError, Registers: []byte{POWER_address, IDACMAG_address}}},
+		},
+		{
+			"FIR at 400 SPS",
+			func(c *Config) { c.Mode2.Rate = Rate400 },
+			[]Violation{{Severity: SeverityError, Registers: []byte{MODE1_address, MODE2_address}}},
+		},
+		{
+			"FIR at 2.5 SPS",
+			func(c *Config) { c.Mode2.Rate = Rate2_5 },
+			nil,
+		},
+		{
+			"sinc4 at 400 SPS",
+			func(c *Config) {
+				c.Mode1.Filter = FilterSinc4
+				c.Mode2.Rate = Rate400
+			},
+			nil,
+		},
+		{
+			"PGA bypass with gain 4",
+			func(c *Config) {
+				c.Mode2.Bypass = true
+				c.Mode2.Gain = Gain4
+			},
+			[]Violation{{Severity: SeverityError, Registers: []byte{MODE2_address}}},
+		},
+		{
+			"same AIN on both ADC1 inputs",
+			func(c *Config) { c.InpMux = InpMux{Positive: AIN2, Negative: AIN2} },
+			[]Violation{{Severity: SeverityWarning, Registers: []byte{INPMUX_address}}},
+		},
+		{
+			"same AIN on both ADC2 inputs",
+			func(c *Config) { c.ADC2Mux = ADC2Mux{Positive: AINCOM, Negative: AINCOM} },
+			[]Violation{{Severity: SeverityWarning, Registers: []byte{ADC2MUX_address}}},
+		},
+		{
+			"temperature sensor on both inputs",
+			func(c *Config) { c.InpMux = InpMux{Positive: TempSensor, Negative: TempSensor} },
+			nil,
+		},
+		{
+			"both IDACs on one pin",
+			func(c *Config) {
+				c.IDACMux = IDACMux{IDAC1: AIN5, IDAC2: AIN5}
+				c.IDACMag = IDACMag{IDAC1: IDAC500uA, IDAC2: IDAC500uA}
+			},
+			[]Violation{{Severity: SeverityWarning, Registers: []byte{IDACMUX_address, IDACMAG_address}}},
+		},
+		{
+			"IDAC not connected",
+			func(c *Config) { c.IDACMag.IDAC2 = IDAC500uA },
+			[]Violation{{Severity: SeverityWarning, Registers: []byte{IDACMUX_address, IDACMAG_address}}},
+		},
+		{
+			"IDAC rotation with the IDACs off",
+			func(c *Config) { c.Mode0.Chop = ChopIDACRotation },
+			[]Violation{{Severity: SeverityWarning, Registers: []byte{MODE0_address, IDACMAG_address}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := DefaultConfig()
+			tt.change(&c)
+			vs := c.Validate()
+			var got []Violation
+			for _, v := range vs {
+				got = append(got, Violation{Severity: v.Severity, Registers: v.Registers})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", vs, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		//shadow changes the register values the device starts with
+		shadow     map[byte]byte
+		values     map[byte]byte
+		validation Validation
+		wantErr    bool
+	}{
+		{
+			name:   "defaults",
+			values: map[byte]byte{MODE2_address: MODE2_default},
+		},
+		{
+			name:    "internal reference turned off under the internal REFMUX",
+			values:  map[byte]byte{POWER_address: POWER_default &^ POWER_intref_mask},
+			wantErr: true,
+		},
+		{
+			name:   "internal reference turned off with an external REFMUX",
+			values: map[byte]byte{POWER_address: POWER_default &^ POWER_intref_mask, REFMUX_address: REFMUX_rmuxP_AIN0 | REFMUX_rmuxN_AIN1},
+		},
+		{
+			name:    "IDAC turned on without the internal reference",
+			shadow:  map[byte]byte{POWER_address: POWER_default &^ POWER_intref_mask, REFMUX_address: REFMUX_rmuxP_AIN0 | REFMUX_rmuxN_AIN1, IDACMUX_address: 0xB4},
+			values:  map[byte]byte{IDACMAG_address: 0x04},
+			wantErr: true,
+		},
+		{
+			name:    "data rate above what the FIR filter supports",
+			values:  map[byte]byte{MODE2_address: MODE2_DR_400},
+			wantErr: true,
+		},
+		{
+			name:   "FIR filter replaced before the data rate goes up",
+			values: map[byte]byte{MODE1_address: MODE1_filter_sinc4, MODE2_address: MODE2_DR_400},
+		},
+		{
+			name:   "same AIN on both inputs is only a warning",
+			values: map[byte]byte{INPMUX_address: 0x22},
+		},
+		{
+			name:   "existing problem in a register that is not written",
+			shadow: map[byte]byte{POWER_address: POWER_default &^ POWER_intref_mask},
+			values: map[byte]byte{INPMUX_address: 0x23},
+		},
+		{
+			name:       "validation off",
+			values:     map[byte]byte{MODE2_address: MODE2_DR_400},
+			validation: ValidateOff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New(nil, Pins{})
+			for reg, value := range tt.shadow {
+				d.regs[reg] = value
+			}
+			d.SetValidation(tt.validation)
+			err := d.checkWrite(tt.values)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("checkWrite() error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("checkWrite() = %v, want ErrInvalidConfig", err)
+			}
+			var configerr *ConfigError
+			if !errors.As(err, &configerr) || len(configerr.Violations.Errors()) == 0 {
+				t.Errorf("checkWrite() = %v, want a ConfigError with the violations", err)
+			}
+		})
+	}
+}
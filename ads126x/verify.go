@@ -0,0 +1,108 @@
+package ads126x
+
+import (
+	"errors"
+	"fmt"
+)
+
+//A register write can be lost or corrupted on the way to the ADC, for example when the chip isn't powered up yet or the SPI wiring is marginal. WriteVerified reads the registers back after writing and writes again if they don't match.
+
+//defaultWriteRetries is how many times WriteVerified writes again by default
+const defaultWriteRetries = 3
+
+//registerNames are the names of the registers from the datasheet, by address
+var registerNames = [numRegisters]string{
+	"ID", "POWER", "INTERFACE", "MODE0", "MODE1", "MODE2", "INPMUX", "OFCAL0", "OFCAL1", "OFCAL2", "FSCAL0", "FSCAL1", "FSCAL2",
+	"IDACMUX", "IDACMAG", "REFMUX", "TDACP", "TDACN", "GPIOCON", "GPIODIR", "GPIODAT", "ADC2CFG", "ADC2MUX", "ADC2OFC0", "ADC2OFC1", "ADC2FSC0", "ADC2FSC1",
+}
+
+//RegisterName is the datasheet name of the register at address
+func RegisterName(address byte) string {
+	if int(address) >= numRegisters {
+		return fmt.Sprintf("0x%02X", address)
+	}
+	return registerNames[address]
+}
+
+//verifyMasks are the bits of each register that read back what was written. The ID register is read only, the reset flag in POWER is set by the chip and GPIODAT reads the level of the GPIO inputs, so they are left out. Registers not listed read back all their bits.
+var verifyMasks = map[byte]byte{
+	ID_address:      0x00,
+	POWER_address:   ^POWER_reset_mask,
+	GPIODAT_address: 0x00,
+}
+
+//verifyMask is the mask of the bits of a register that read back what was written
+func verifyMask(address byte) byte {
+	if mask, ok := verifyMasks[address]; ok {
+		return mask
+	}
+	return 0xFF
+}
+
+//RegisterDiff is a register that read back differently from what was written
+type RegisterDiff struct {
+	Address byte
+	Wrote   byte
+	Read    byte
+	//Mask is the bits that were compared
+	Mask byte
+}
+
+func (d RegisterDiff) String() string {
+	return fmt.Sprintf("%s (0x%02X) wrote 0x%02X read 0x%02X", RegisterName(d.Address), d.Address, d.Wrote, d.Read)
+}
+
+//diffRegisters compares consecutive registers written and read back, leaving out the bits that don't read back what was written
+func diffRegisters(startingreg byte, wrote, read []byte) []RegisterDiff {
+	var diffs []RegisterDiff
+	for i := range wrote {
+		address := startingreg + byte(i)
+		mask := verifyMask(address)
+		if i >= len(read) || wrote[i]&mask != read[i]&mask {
+			diff := RegisterDiff{Address: address, Wrote: wrote[i], Mask: mask}
+			if i < len(read) {
+				diff.Read = read[i]
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+//SetWriteRetries sets how many times WriteVerified writes again when the readback doesn't match. The default is 3.
+func (d *Device) SetWriteRetries(retries int) {
+	d.mu.Lock()
+	d.writeretries = retries
+	d.mu.Unlock()
+}
+
+//WriteVerified writes data to consecutive registers starting at startingreg, reads them back and writes again if they don't match, up to the number of retries set with SetWriteRetries. If they still don't match it returns the RegisterMismatchError of the last attempt, which lists the registers that differ. SPI failures are retried the same way. Settings that don't pass validation are not retried.
+func (d *Device) WriteVerified(startingreg byte, data []byte) error {
+	d.mu.Lock()
+	retries := d.writeretries
+	d.mu.Unlock()
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if err = d.WriteRegisters(startingreg, data); err == nil {
+			err = d.CheckRegisters(startingreg, data)
+		}
+		if err == nil || errors.Is(err, ErrInvalidConfig) {
+			return err
+		}
+	}
+	return err
+}
+
+//WriteConfigVerified is like WriteConfig but verifies each register with WriteVerified
+func (d *Device) WriteConfigVerified(configs ...RegisterConfig) error {
+	for _, c := range configs {
+		value, err := c.Encode()
+		if err != nil {
+			return err
+		}
+		if err := d.WriteVerified(c.Address(), []byte{value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
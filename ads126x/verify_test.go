@@ -0,0 +1,80 @@
+package ads126x
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRegisters(t *testing.T) {
+	tests := []struct {
+		name  string
+		start byte
+		wrote []byte
+		read  []byte
+		want  []RegisterDiff
+	}{
+		{
+			name:  "match",
+			start: INTERFACE_address,
+			wrote: []byte{0x05, 0x40, 0x80},
+			read:  []byte{0x05, 0x40, 0x80},
+		},
+		{
+			name:  "ID is not compared",
+			start: ID_address,
+			wrote: []byte{0x00, 0x01},
+			read:  []byte{0x23, 0x01},
+		},
+		{
+			name:  "POWER reset flag is not compared",
+			start: POWER_address,
+			wrote: []byte{0x01},
+			read:  []byte{0x11},
+		},
+		{
+			name:  "POWER other bits are compared",
+			start: POWER_address,
+			wrote: []byte{0x11},
+			read:  []byte{0x10},
+			want:  []RegisterDiff{{Address: POWER_address, Wrote: 0x11, Read: 0x10, Mask: ^POWER_reset_mask}},
+		},
+		{
+			name:  "GPIODAT is not compared",
+			start: GPIOCON_address,
+			wrote: []byte{0x01, 0x00, 0x01},
+			read:  []byte{0x01, 0x00, 0xFE},
+		},
+		{
+			name:  "GPIODIR is compared",
+			start: GPIOCON_address,
+			wrote: []byte{0x01, 0x00, 0x01},
+			read:  []byte{0x01, 0x01, 0x01},
+			want:  []RegisterDiff{{Address: GPIODIR_address, Wrote: 0x00, Read: 0x01, Mask: 0xFF}},
+		},
+		{
+			name:  "several registers differ",
+			start: MODE0_address,
+			wrote: []byte{0x40, 0x80, 0x04},
+			read:  []byte{0x00, 0x80, 0x05},
+			want: []RegisterDiff{
+				{Address: MODE0_address, Wrote: 0x40, Read: 0x00, Mask: 0xFF},
+				{Address: MODE2_address, Wrote: 0x04, Read: 0x05, Mask: 0xFF},
+			},
+		},
+		{
+			name:  "short read",
+			start: MODE0_address,
+			wrote: []byte{0x40, 0x80},
+			read:  []byte{0x40},
+			want:  []RegisterDiff{{Address: MODE1_address, Wrote: 0x80, Mask: 0xFF}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffRegisters(tt.start, tt.wrote, tt.read)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffRegisters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package ads126x
+
+import (
+	"math"
+
+	"periph.io/x/periph/conn/physic"
+)
+
+//The conversion data is a fraction of the full-scale range, which is ±Vref/gain. ADC1 codes are 32 bit, so 2^31 is full scale, and ADC2 codes are 24 bit, so 2^23 is full scale. With the PGA bypassed the gain is always one.
+
+//InternalReference is the voltage of the internal reference
+const InternalReference = 2.5
+
+//Scale turns conversion codes into the voltage at the ADC inputs
+type Scale struct {
+	//Reference is the reference voltage in volts
+	Reference float64
+	//Gain is the PGA gain in V/V
+	Gain float64
+	//FullScaleCode is the code for a full-scale positive input (2^31 for ADC1 and 2^23 for ADC2)
+	FullScaleCode float64
+}
+
+//ADC1Scale is the scale for ADC1 codes with the given reference voltage and MODE2 settings
+func ADC1Scale(reference float64, mode2 Mode2) Scale {
+	gain := 1.0
+	if !mode2.Bypass {
+		gain = float64(mode2.Gain.Factor())
+	}
+	return Scale{Reference: reference, Gain: gain, FullScaleCode: 1 << 31}
+}
+
+//ADC2Scale is the scale for ADC2 codes with the given reference voltage and ADC2CFG gain
+func ADC2Scale(reference float64, config ADC2Config) Scale {
+	return Scale{Reference: reference, Gain: float64(config.Gain.Factor()), FullScaleCode: 1 << 23}
+}
+
+//Volts converts a conversion code into volts
+func (s Scale) Volts(code int32) float64 {
+	return float64(code) / s.FullScaleCode * s.Reference / s.Gain
+}
+
+//Potential converts a conversion code into a physic.ElectricPotential. It is rounded to the nearest nanovolt.
+func (s Scale) Potential(code int32) physic.ElectricPotential {
+	return physic.ElectricPotential(math.Round(s.Volts(code) * float64(physic.Volt)))
+}
+
+//FullScale is the input voltage that gives the largest positive code
+func (s Scale) FullScale() float64 {
+	return s.Reference / s.Gain
+}
+
+//SetReferenceVoltage sets the voltage of the external reference, in volts. It is used instead of the internal 2.5 V whenever REFMUX (or REF2 in ADC2CFG for ADC2) selects anything other than the internal reference, including the analog supply. The default is 2.5 V.
+func (d *Device) SetReferenceVoltage(volts float64) {
+	d.mu.Lock()
+	d.extref = volts
+	d.mu.Unlock()
+}
+
+//ReferenceVoltage is the ADC1 reference voltage selected by the REFMUX register
+func (d *Device) ReferenceVoltage() float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var ref RefMux
+	ref.Decode(d.regs[REFMUX_address])
+	if ref.Positive == RefPInternal && ref.Negative == RefNInternal {
+		return InternalReference
+	}
+	return d.extref
+}
+
+//Scale is the ADC1 scale for the active configuration - the gain in MODE2 and the reference in REFMUX
+func (d *Device) Scale() Scale {
+	return ADC1Scale(d.ReferenceVoltage(), d.activeMode2())
+}
+
+//ChannelScale is the ADC1 scale for the gain of a channel config with the active reference, for converting data read with a different channel than the one currently applied
+func (d *Device) ChannelScale(channel ChannelConfig) Scale {
+	return ADC1Scale(d.ReferenceVoltage(), channel.Mode2)
+}
+
+//ADC2Scale is the ADC2 scale for the active ADC2CFG settings
+func (d *Device) ADC2Scale() Scale {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var cfg ADC2Config
+	cfg.Decode(d.regs[ADC2CFG_address])
+	reference := d.extref
+	if cfg.Ref == ADC2RefInternal {
+		reference = InternalReference
+	}
+	return ADC2Scale(reference, cfg)
+}
+
+//Volts converts ADC1 conversion data into volts with the active configuration
+func (d *Device) Volts(code int32) float64 {
+	return d.Scale().Volts(code)
+}
+
+//ADC2Volts converts ADC2 conversion data into volts with the active configuration
+func (d *Device) ADC2Volts(code int32) float64 {
+	return d.ADC2Scale().Volts(code)
+}
+
+//activeMode2 decodes the cached MODE2 register
+func (d *Device) activeMode2() Mode2 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var mode2 Mode2
+	mode2.Decode(d.regs[MODE2_address])
+	return mode2
+}
@@ -0,0 +1,139 @@
+package ads126x
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+//drdyWaitStep is how often a read waiting on DRDY checks if its context was cancelled
+const drdyWaitStep = 20 * time.Millisecond
+
+//timeoutMargin is added to the worked out conversion time to allow for the Pi not running in real time
+const timeoutMargin = 100 * time.Millisecond
+
+//SetTimeout sets how long reads wait for new conversion data before returning a TimeoutError. Zero, the default, works it out from the data rate, filter, chop mode and conversion delay set in the MODE registers. A negative value waits forever.
+func (d *Device) SetTimeout(timeout time.Duration) {
+	d.mu.Lock()
+	d.timeout = timeout
+	d.mu.Unlock()
+}
+
+//Timeout is how long reads currently wait for new conversion data. A negative value means forever.
+func (d *Device) Timeout() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.conversionTimeout()
+}
+
+//conversionTimeout is the configured timeout, or if there is none, enough time for the first conversion after a start with the current MODE settings (see Settling). d.mu must be held.
+func (d *Device) conversionTimeout() time.Duration {
+	if d.timeout != 0 {
+		return d.timeout
+	}
+	return d.settling().FirstConversion + timeoutMargin
+}
+
+//waitDRDY waits for the DRDY pin to go low. It gives up with a TimeoutError after timeout (negative means never) or with the context's error once ctx is done.
+func (d *Device) waitDRDY(ctx context.Context, timeout time.Duration) error {
+	start := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		step := drdyWaitStep
+		if timeout >= 0 {
+			remaining := timeout - time.Since(start)
+			if remaining <= 0 {
+				return &TimeoutError{Waited: time.Since(start)}
+			}
+			if remaining < step {
+				step = remaining
+			}
+		}
+		if d.pins.Drdy.WaitForEdge(step) {
+			return nil
+		}
+	}
+}
+
+//ReadContext is like ReadSample but stops waiting when ctx is done, returning the context's error. If DRDY doesn't go low within the device's timeout (see SetTimeout) it returns a TimeoutError, which can be checked with errors.Is(err, ErrDRDYTimeout).
+func (d *Device) ReadContext(ctx context.Context) (Sample, error) {
+	if d.pins.Drdy == nil {
+		return Sample{}, ErrNoDRDYPin
+	}
+	if err := d.waitDRDY(ctx, d.Timeout()); err != nil {
+		return Sample{}, err
+	}
+	return d.applyClipPolicy(d.readDirect())
+}
+
+//ReadNextByCommandContext is like ReadNextByCommand, using the device's timeout (see SetTimeout), but stops waiting when ctx is done
+func (d *Device) ReadNextByCommandContext(ctx context.Context) (Sample, error) {
+	return d.applyClipPolicy(d.readNextByCommand(ctx, d.Timeout()))
+}
+
+//readNextByCommand waits on DRDY, or polls the status byte if there is no DRDY pin, and then reads the new conversion with RDATA1
+func (d *Device) readNextByCommand(ctx context.Context, timeout time.Duration) (Sample, error) {
+	if d.pins.Drdy != nil {
+		if err := d.waitDRDY(ctx, timeout); err != nil {
+			return Sample{}, err
+		}
+		sample, err := d.readSampleByCommand()
+		if err != nil {
+			return sample, err
+		}
+		if sample.HasStatus && !sample.Status.ADC1NewData() {
+			return sample, ErrDuplicate
+		}
+		return sample, nil
+	}
+	if err := d.needStatus(); err != nil {
+		return Sample{}, err
+	}
+	d.mu.Lock()
+	interval := d.pollinterval
+	d.mu.Unlock()
+	start := time.Now()
+	for {
+		sample, err := d.readSampleByCommand()
+		if err != nil || sample.Status.ADC1NewData() {
+			return sample, err
+		}
+		if timeout >= 0 && time.Since(start) > timeout {
+			return Sample{}, &TimeoutError{Waited: time.Since(start)}
+		}
+		select {
+		case <-ctx.Done():
+			return Sample{}, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+//Stream reads ADC1 conversions continuously and sends them to samples until ctx is done, in which case it returns the context's error. Conversions are started beforehand and stopped afterwards. It reads in continuous read mode if the device has a DRDY pin and polls by command otherwise. Samples with a failed checksum or CRC are skipped, and so are clipped samples if the clip policy is ClipDrop. Any other error, including a TimeoutError when the ADC stops converting, ends the stream and is returned so the caller can decide what to do, for example reset the chip and start again.
+func (d *Device) Stream(ctx context.Context, samples chan<- Sample) error {
+	if err := d.Start(); err != nil {
+		return err
+	}
+	//the stream always ends with an error so a failure to stop on top of it is not reported
+	defer d.Stop()
+	read := d.ReadContext
+	if d.pins.Drdy == nil {
+		read = d.ReadNextByCommandContext
+	}
+	for {
+		sample, err := read(ctx)
+		if errors.Is(err, ErrChecksum) || errors.Is(err, ErrClipped) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case samples <- sample:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
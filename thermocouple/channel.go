@@ -0,0 +1,105 @@
+package thermocouple
+
+import "github.com/AnnaKnapp/piadcs/ads126x"
+
+//Channel is a thermocouple connected to ADC1 of an ADS126x. The voltage is measured against the internal reference unless Reference is set, and converted to °C with the cold junction temperature given to Celsius or Measure, which can come from the ADS126x temperature sensor, an RTD on another channel or any other sensor at the terminals.
+type Channel struct {
+	Type     Type
+	Positive ads126x.Input
+	Negative ads126x.Input
+	Gain     ads126x.Gain
+	Rate     ads126x.DataRate
+	Filter   ads126x.Filter
+	//Ref selects the reference inputs. The zero value is the internal 2.5 V reference.
+	Ref ads126x.RefMux
+	//Reference is the reference voltage in volts. Zero means ads126x.InternalReference.
+	Reference float64
+	//Bias enables the level shift voltage on AINCOM, which keeps a thermocouple that isn't grounded inside the input range when AINCOM is its negative input
+	Bias bool
+	//Offset is a measured offset in mV, for example from shorting the inputs, that is subtracted from every reading
+	Offset float64
+}
+
+//ChannelConfig is the INPMUX and MODE2 settings of the channel
+func (c Channel) ChannelConfig() ads126x.ChannelConfig {
+	return ads126x.ChannelConfig{
+		Mux:   ads126x.InpMux{Positive: c.Positive, Negative: c.Negative},
+		Mode2: ads126x.Mode2{Gain: c.Gain, Rate: c.Rate},
+	}
+}
+
+//ScanChannel is the channel for use with an ads126x Scanner, with the level shift voltage on AINCOM if Bias is set. Samples it returns are converted with Celsius.
+func (c Channel) ScanChannel(name string) ads126x.ScanChannel {
+	return ads126x.ScanChannel{
+		Name:          name,
+		ChannelConfig: c.ChannelConfig(),
+		Mode1:         ads126x.Mode1{Filter: c.Filter},
+		Ref:           c.Ref,
+		VBias:         c.Bias,
+	}
+}
+
+//reference is the reference voltage in volts
+func (c Channel) reference() float64 {
+	if c.Reference == 0 {
+		return ads126x.InternalReference
+	}
+	return c.Reference
+}
+
+//Millivolts converts ADC1 conversion data taken with the channel settings into the thermocouple voltage in mV, with the offset taken off
+func (c Channel) Millivolts(code int32) float64 {
+	return ads126x.ADC1Scale(c.reference(), c.ChannelConfig().Mode2).Volts(code)*1000 - c.Offset
+}
+
+//Celsius converts ADC1 conversion data taken with the channel settings into the hot junction temperature in °C, with the cold junction at coldjunction °C
+func (c Channel) Celsius(code int32, coldjunction float64) (float64, error) {
+	return c.Type.Compensate(c.Millivolts(code), coldjunction)
+}
+
+//Reading is a thermocouple measurement
+type Reading struct {
+	ads126x.Sample
+	//Millivolts is the thermocouple voltage
+	Millivolts float64
+	//ColdJunction is the cold junction temperature in °C used for the conversion
+	ColdJunction float64
+	//Celsius is the hot junction temperature in °C
+	Celsius float64
+}
+
+//Configure writes the registers for measuring the thermocouple - the inputs, gain, data rate, filter and reference, and the level shift voltage if Bias is set. The internal reference is switched on when it is used.
+func (c Channel) Configure(dev *ads126x.Device) error {
+	return dev.UpdateConfig(func(config *ads126x.Config) {
+		if c.Ref.Positive == ads126x.RefPInternal || c.Ref.Negative == ads126x.RefNInternal {
+			config.Power.InternalRef = true
+		}
+		if c.Bias {
+			config.Power.VBias = true
+		}
+		config.Mode1.Filter = c.Filter
+		config.Mode2 = c.ChannelConfig().Mode2
+		config.InpMux = c.ChannelConfig().Mux
+		config.RefMux = c.Ref
+	})
+}
+
+//Measure configures the ADC for the thermocouple and takes a single conversion in pulse mode, which is settled, and converts it to °C with the cold junction at coldjunction °C. ADC1 is left in pulse mode.
+func (c Channel) Measure(dev *ads126x.Device, coldjunction float64) (Reading, error) {
+	if err := dev.Stop(); err != nil {
+		return Reading{}, err
+	}
+	if err := c.Configure(dev); err != nil {
+		return Reading{}, err
+	}
+	if err := dev.SetRunMode(ads126x.RunPulse); err != nil {
+		return Reading{}, err
+	}
+	sample, err := dev.ReadOnce(dev.Timeout())
+	if err != nil {
+		return Reading{Sample: sample}, err
+	}
+	reading := Reading{Sample: sample, Millivolts: c.Millivolts(sample.Raw), ColdJunction: coldjunction}
+	reading.Celsius, err = c.Type.Compensate(reading.Millivolts, coldjunction)
+	return reading, err
+}
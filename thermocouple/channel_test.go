@@ -0,0 +1,32 @@
+package thermocouple
+
+import (
+	"testing"
+
+	"github.com/AnnaKnapp/piadcs/ads126x"
+)
+
+func TestChannelScanChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel Channel
+	}{
+		{"bias", Channel{Type: K, Positive: ads126x.AIN9, Negative: ads126x.AINCOM, Gain: ads126x.Gain32, Rate: ads126x.Rate20, Filter: ads126x.FilterSinc4, Bias: true}},
+		{"no bias", Channel{Type: J, Positive: ads126x.AIN2, Negative: ads126x.AIN3, Gain: ads126x.Gain16, Rate: ads126x.Rate20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.channel
+			got := c.ScanChannel(tt.name)
+			if got.VBias != c.Bias {
+				t.Errorf("VBias = %v, want %v", got.VBias, c.Bias)
+			}
+			if got.Name != tt.name || got.Mux != (ads126x.InpMux{Positive: c.Positive, Negative: c.Negative}) {
+				t.Errorf("ScanChannel = %+v, wrong name or inputs", got)
+			}
+			if got.Mode2 != (ads126x.Mode2{Gain: c.Gain, Rate: c.Rate}) || got.Mode1.Filter != c.Filter {
+				t.Errorf("ScanChannel = %+v, wrong MODE1 or MODE2", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+//Package thermocouple converts between thermocouple voltages and temperatures for the standard letter types with the NIST ITS-90 reference functions, and measures thermocouples with an ADS126x
+package thermocouple
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+//Type is a standard thermocouple type
+type Type byte
+
+//The standard thermocouple types of IEC 60584 and NIST Monograph 175
+const (
+	B Type = iota + 1
+	E
+	J
+	K
+	N
+	R
+	S
+	T
+)
+
+func (t Type) String() string {
+	if t < B || t > T {
+		return fmt.Sprintf("Type(%d)", byte(t))
+	}
+	return "type " + string("BEJKNRST"[t-B])
+}
+
+//ErrOutOfRange means a temperature or voltage is outside the range of the NIST reference functions for the thermocouple type
+var ErrOutOfRange = errors.New("thermocouple: out of range")
+
+//RangeError is returned when a temperature or voltage is outside the range of the reference functions
+type RangeError struct {
+	Type Type
+	//Value is the temperature in °C or the EMF in mV that was out of range
+	Value    float64
+	Min, Max float64
+	//Unit is "°C" or "mV"
+	Unit string
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("thermocouple: %g %s is outside the %v range of %g to %g %s", e.Value, e.Unit, e.Type, e.Min, e.Max, e.Unit)
+}
+
+//Is makes errors.Is(err, ErrOutOfRange) true for every RangeError
+func (e *RangeError) Is(target error) bool { return target == ErrOutOfRange }
+
+//table returns the reference functions of the type
+func (t Type) table() (table, error) {
+	tab, ok := tables[t]
+	if !ok {
+		return table{}, fmt.Errorf("thermocouple: unknown thermocouple %v", t)
+	}
+	return tab, nil
+}
+
+//find returns the polynomial of the piecewise function that covers x
+func find(pieces []polynomial, t Type, x float64, unit string) (polynomial, error) {
+	for _, p := range pieces {
+		if x >= p.min && x <= p.max {
+			return p, nil
+		}
+	}
+	return polynomial{}, &RangeError{Type: t, Value: x, Min: pieces[0].min, Max: pieces[len(pieces)-1].max, Unit: unit}
+}
+
+//Range is the temperature range in °C that the type's reference function covers
+func (t Type) Range() (min, max float64) {
+	tab, err := t.table()
+	if err != nil {
+		return 0, 0
+	}
+	return tab.forward[0].min, tab.forward[len(tab.forward)-1].max
+}
+
+//EMF is the thermoelectric voltage in mV of the thermocouple with its hot junction at celsius and its cold junction at 0 °C
+func (t Type) EMF(celsius float64) (float64, error) {
+	tab, err := t.table()
+	if err != nil {
+		return 0, err
+	}
+	p, err := find(tab.forward, t, celsius, "°C")
+	if err != nil {
+		return 0, err
+	}
+	emf := p.eval(celsius)
+	if t == K && celsius > 0 {
+		a := kExponential
+		emf += a[0] * math.Exp(a[1]*(celsius-a[2])*(celsius-a[2]))
+	}
+	return emf, nil
+}
+
+//Temperature is the hot junction temperature in °C that gives a voltage of millivolts with the cold junction at 0 °C. The inverse functions cover a smaller range than EMF, for example type B only from 250 °C.
+func (t Type) Temperature(millivolts float64) (float64, error) {
+	tab, err := t.table()
+	if err != nil {
+		return 0, err
+	}
+	p, err := find(tab.inverse, t, millivolts, "mV")
+	if err != nil {
+		return 0, err
+	}
+	return p.eval(millivolts), nil
+}
+
+//Compensate is the hot junction temperature in °C for a measured voltage of millivolts with the cold junction at coldjunction °C. The voltage the thermocouple would make from 0 °C to the cold junction temperature is added to the measured voltage before converting it.
+func (t Type) Compensate(millivolts, coldjunction float64) (float64, error) {
+	cj, err := t.EMF(coldjunction)
+	if err != nil {
+		return 0, fmt.Errorf("thermocouple: cold junction: %w", err)
+	}
+	return t.Temperature(millivolts + cj)
+}
@@ -0,0 +1,175 @@
+package thermocouple
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+//nistPoints are values from the NIST ITS-90 thermocouple tables, which are rounded to 1 µV
+var nistPoints = []struct {
+	typ        Type
+	celsius    float64
+	millivolts float64
+}{
+	{B, 300, 0.431},
+	{B, 1000, 4.834},
+	{B, 1820, 13.820},
+	{E, -200, -8.825},
+	{E, 100, 6.319},
+	{E, 500, 37.005},
+	{E, 1000, 76.373},
+	{J, -200, -7.890},
+	{J, 100, 5.269},
+	{J, 500, 27.393},
+	{J, 1000, 57.953},
+	{J, 1200, 69.553},
+	{K, -200, -5.891},
+	{K, -100, -3.554},
+	{K, 100, 4.096},
+	{K, 500, 20.644},
+	{K, 1000, 41.276},
+	{K, 1372, 54.886},
+	{N, -200, -3.990},
+	{N, 100, 2.774},
+	{N, 1000, 36.256},
+	{N, 1300, 47.513},
+	{R, -50, -0.226},
+	{R, 100, 0.647},
+	{R, 1000, 10.506},
+	{R, 1500, 17.451},
+	{S, -40, -0.194},
+	{S, 100, 0.646},
+	{S, 1000, 9.587},
+	{S, 1500, 15.582},
+	{T, -200, -5.603},
+	{T, 100, 4.279},
+	{T, 400, 20.872},
+}
+
+func TestEMF(t *testing.T) {
+	for _, p := range nistPoints {
+		got, err := p.typ.EMF(p.celsius)
+		if err != nil {
+			t.Errorf("%v EMF(%v) error: %v", p.typ, p.celsius, err)
+			continue
+		}
+		if math.Abs(got-p.millivolts) > 0.0005 {
+			t.Errorf("%v EMF(%v) = %.4f mV, want %.3f mV", p.typ, p.celsius, got, p.millivolts)
+		}
+	}
+	//the reference functions are for a cold junction at 0 °C
+	for typ := B; typ <= T; typ++ {
+		if got, err := typ.EMF(0); err != nil || math.Abs(got) > 1e-12 {
+			t.Errorf("%v EMF(0) = %v, %v, want 0", typ, got, err)
+		}
+	}
+}
+
+func TestTemperature(t *testing.T) {
+	for _, p := range nistPoints {
+		got, err := p.typ.Temperature(p.millivolts)
+		if err != nil {
+			t.Errorf("%v Temperature(%v) error: %v", p.typ, p.millivolts, err)
+			continue
+		}
+		//the table values are rounded to 1 µV, which is a few tenths of a °C where the sensitivity is low
+		min, max := p.typ.Range()
+		lo, hi := math.Max(p.celsius-0.5, min), math.Min(p.celsius+0.5, max)
+		below, _ := p.typ.EMF(lo)
+		above, _ := p.typ.EMF(hi)
+		tolerance := 0.06 + 0.0005*(hi-lo)/(above-below)
+		if math.Abs(got-p.celsius) > tolerance {
+			t.Errorf("%v Temperature(%v) = %.3f °C, want %v °C", p.typ, p.millivolts, got, p.celsius)
+		}
+	}
+}
+
+func TestInverseAgreesWithForward(t *testing.T) {
+	//the temperature ranges the inverse functions cover
+	tests := []struct {
+		typ      Type
+		min, max float64
+	}{
+		{B, 250, 1820},
+		{E, -200, 1000},
+		{J, -210, 1200},
+		{K, -200, 1372},
+		{N, -200, 1300},
+		{R, -50, 1768.1},
+		{S, -50, 1768.1},
+		{T, -200, 400},
+	}
+	for _, tt := range tests {
+		for celsius := tt.min + 0.5; celsius < tt.max; celsius += 0.5 {
+			emf, err := tt.typ.EMF(celsius)
+			if err != nil {
+				t.Fatalf("%v EMF(%v) error: %v", tt.typ, celsius, err)
+			}
+			got, err := tt.typ.Temperature(emf)
+			if err != nil {
+				t.Fatalf("%v Temperature(EMF(%v)) error: %v", tt.typ, celsius, err)
+			}
+			if math.Abs(got-celsius) > 0.06 {
+				t.Errorf("%v Temperature(EMF(%v)) = %.3f °C", tt.typ, celsius, got)
+			}
+		}
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func() (float64, error)
+	}{
+		{"K EMF above range", func() (float64, error) { return K.EMF(1400) }},
+		{"K EMF below range", func() (float64, error) { return K.EMF(-280) }},
+		{"R EMF below range", func() (float64, error) { return R.EMF(-60) }},
+		{"B EMF below range", func() (float64, error) { return B.EMF(-10) }},
+		{"K Temperature above range", func() (float64, error) { return K.Temperature(60) }},
+		{"B Temperature below range", func() (float64, error) { return B.Temperature(0.1) }},
+		{"T Temperature below range", func() (float64, error) { return T.Temperature(-6) }},
+		{"J cold junction out of range", func() (float64, error) { return J.Compensate(1, -300) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f()
+			if !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("got %v, %v, want ErrOutOfRange", got, err)
+			}
+			var rangeerr *RangeError
+			if !errors.As(err, &rangeerr) {
+				t.Errorf("error %v is not a RangeError", err)
+			}
+		})
+	}
+	if _, err := Type(0).EMF(100); err == nil || errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Type(0).EMF error = %v, want an unknown type error", err)
+	}
+}
+
+func TestCompensate(t *testing.T) {
+	tests := []struct {
+		typ          Type
+		celsius      float64
+		coldjunction float64
+	}{
+		{K, 100, 25},
+		{K, -50, 30},
+		{J, 400, 22},
+		{T, -150, 20},
+		{S, 1200, 35},
+	}
+	for _, tt := range tests {
+		hot, _ := tt.typ.EMF(tt.celsius)
+		cold, _ := tt.typ.EMF(tt.coldjunction)
+		got, err := tt.typ.Compensate(hot-cold, tt.coldjunction)
+		if err != nil {
+			t.Errorf("%v Compensate error: %v", tt.typ, err)
+			continue
+		}
+		if math.Abs(got-tt.celsius) > 0.06 {
+			t.Errorf("%v Compensate(EMF(%v) - EMF(%v), %v) = %.3f °C", tt.typ, tt.celsius, tt.coldjunction, tt.coldjunction, got)
+		}
+	}
+}